@@ -0,0 +1,144 @@
+package fu
+
+import (
+	"context"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/numeric"
+)
+
+func Quotient() bifunction.B {
+	return arithmetic(numeric.Div)
+}
+
+func Remainder() bifunction.B {
+	return arithmetic(numeric.Mod)
+}
+
+func Power() bifunction.B {
+	return arithmetic(numeric.Pow)
+}
+
+func Div(a interface{}) function.F {
+	return ApplyEnd(Quotient(), a)
+}
+
+func Mod(a interface{}) function.F {
+	return ApplyEnd(Remainder(), a)
+}
+
+func Pow(a interface{}) function.F {
+	return ApplyEnd(Power(), a)
+}
+
+func Neg() function.F {
+	return unary(numeric.Wrapping, numeric.Neg)
+}
+
+func Abs() function.F {
+	return unary(numeric.Wrapping, numeric.Abs)
+}
+
+func unary(m numeric.Mode, op numeric.Op) function.F {
+	return function.New(
+		func(ctx context.Context, a interface{}) (interface{}, error) {
+			return m.ApplyUnary(op, a)
+		})
+}
+
+func CheckedSum() bifunction.B {
+	return arithmeticMode(numeric.Checked, numeric.Add)
+}
+
+func CheckedNegativeSum() bifunction.B {
+	return arithmeticMode(numeric.Checked, numeric.Sub)
+}
+
+func CheckedMultiply() bifunction.B {
+	return arithmeticMode(numeric.Checked, numeric.Mul)
+}
+
+func CheckedQuotient() bifunction.B {
+	return arithmeticMode(numeric.Checked, numeric.Div)
+}
+
+func CheckedPower() bifunction.B {
+	return arithmeticMode(numeric.Checked, numeric.Pow)
+}
+
+func CheckedAdd(a interface{}) function.F {
+	return Apply(a, CheckedSum())
+}
+
+func CheckedSub(a interface{}) function.F {
+	return ApplyEnd(CheckedNegativeSum(), a)
+}
+
+func CheckedMul(a interface{}) function.F {
+	return Apply(a, CheckedMultiply())
+}
+
+func CheckedDiv(a interface{}) function.F {
+	return ApplyEnd(CheckedQuotient(), a)
+}
+
+func CheckedPow(a interface{}) function.F {
+	return ApplyEnd(CheckedPower(), a)
+}
+
+func CheckedNeg() function.F {
+	return unary(numeric.Checked, numeric.Neg)
+}
+
+func CheckedAbs() function.F {
+	return unary(numeric.Checked, numeric.Abs)
+}
+
+func SaturatingSum() bifunction.B {
+	return arithmeticMode(numeric.Saturating, numeric.Add)
+}
+
+func SaturatingNegativeSum() bifunction.B {
+	return arithmeticMode(numeric.Saturating, numeric.Sub)
+}
+
+func SaturatingMultiply() bifunction.B {
+	return arithmeticMode(numeric.Saturating, numeric.Mul)
+}
+
+func SaturatingQuotient() bifunction.B {
+	return arithmeticMode(numeric.Saturating, numeric.Div)
+}
+
+func SaturatingPower() bifunction.B {
+	return arithmeticMode(numeric.Saturating, numeric.Pow)
+}
+
+func SaturatingAdd(a interface{}) function.F {
+	return Apply(a, SaturatingSum())
+}
+
+func SaturatingSub(a interface{}) function.F {
+	return ApplyEnd(SaturatingNegativeSum(), a)
+}
+
+func SaturatingMul(a interface{}) function.F {
+	return Apply(a, SaturatingMultiply())
+}
+
+func SaturatingDiv(a interface{}) function.F {
+	return ApplyEnd(SaturatingQuotient(), a)
+}
+
+func SaturatingPow(a interface{}) function.F {
+	return ApplyEnd(SaturatingPower(), a)
+}
+
+func SaturatingNeg() function.F {
+	return unary(numeric.Saturating, numeric.Neg)
+}
+
+func SaturatingAbs() function.F {
+	return unary(numeric.Saturating, numeric.Abs)
+}
@@ -0,0 +1,132 @@
+package fu
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/numeric"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArithmetic(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		f    function.F
+		in   interface{}
+		out  interface{}
+		err  error
+	}{
+		{desc: "div", f: Div(2), in: 7, out: 3},
+		{desc: "div float", f: Div(2.0), in: 7.0, out: 3.5},
+		{desc: "div duration", f: Div(time.Duration(2)), in: time.Minute, out: 30 * time.Second},
+		{desc: "div by zero", f: Div(0), in: 7, err: numeric.ErrDivideByZero},
+		{desc: "div float by zero", f: Div(0.0), in: 7.0, err: numeric.ErrDivideByZero},
+		{desc: "div uint by zero", f: Div(uint(0)), in: uint(7), err: numeric.ErrDivideByZero},
+		{desc: "div min by -1 wraps", f: Div(int8(-1)), in: int8(math.MinInt8), out: int8(math.MinInt8)},
+		{desc: "mod", f: Mod(3), in: 7, out: 1},
+		{desc: "mod negative", f: Mod(3), in: -7, out: -1},
+		{desc: "mod float", f: Mod(2.5), in: 7.0, out: 2.0},
+		{desc: "mod by zero", f: Mod(0), in: 7, err: numeric.ErrDivideByZero},
+		{desc: "mod complex", f: Mod(complex(1, 0)), in: complex(1, 0), err: errors.New("")},
+		{desc: "pow", f: Pow(10), in: 2, out: 1024},
+		{desc: "pow zero", f: Pow(0), in: 2, out: 1},
+		{desc: "pow negative base", f: Pow(3), in: -2, out: -8},
+		{desc: "pow uint", f: Pow(uint8(2)), in: uint8(15), out: uint8(225)},
+		{desc: "pow float", f: Pow(0.5), in: 9.0, out: 3.0},
+		{desc: "pow negative exponent", f: Pow(-1), in: 2, err: errors.New("")},
+		{desc: "neg", f: Neg(), in: 3, out: -3},
+		{desc: "neg float", f: Neg(), in: 3.5, out: -3.5},
+		{desc: "neg uint wraps", f: Neg(), in: uint8(1), out: uint8(255)},
+		{desc: "neg min wraps", f: Neg(), in: int8(math.MinInt8), out: int8(math.MinInt8)},
+		{desc: "abs", f: Abs(), in: -3, out: 3},
+		{desc: "abs float", f: Abs(), in: -3.5, out: 3.5},
+		{desc: "abs uint", f: Abs(), in: uint(3), out: uint(3)},
+		{desc: "abs complex", f: Abs(), in: complex(3, 4), out: 5.0},
+		{desc: "abs nil", f: Abs(), in: nil, err: numeric.ErrNil},
+		{desc: "abs string", f: Abs(), in: "a", err: numeric.ErrNotNumeric},
+
+		{desc: "checked add", f: CheckedAdd(1), in: 1, out: 2},
+		{desc: "checked add overflow", f: CheckedAdd(int64(1)), in: int64(math.MaxInt64), err: numeric.ErrOverflow},
+		{desc: "checked add underflow", f: CheckedAdd(int8(-1)), in: int8(math.MinInt8), err: numeric.ErrOverflow},
+		{desc: "checked add narrow", f: CheckedAdd(int8(1)), in: int8(math.MaxInt8), err: numeric.ErrOverflow},
+		{desc: "checked add uint", f: CheckedAdd(uint64(1)), in: uint64(math.MaxUint64), err: numeric.ErrOverflow},
+		{desc: "checked add uint8", f: CheckedAdd(uint8(1)), in: uint8(255), err: numeric.ErrOverflow},
+		{desc: "checked add float", f: CheckedAdd(math.MaxFloat64), in: math.MaxFloat64, err: numeric.ErrOverflow},
+		{desc: "checked add float32", f: CheckedAdd(float32(math.MaxFloat32)), in: float32(math.MaxFloat32), err: numeric.ErrOverflow},
+		{desc: "checked add inf", f: CheckedAdd(math.Inf(1)), in: 1.0, out: math.Inf(1)},
+		{desc: "checked sub", f: CheckedSub(1), in: 3, out: 2},
+		{desc: "checked sub overflow", f: CheckedSub(int64(1)), in: int64(math.MinInt64), err: numeric.ErrOverflow},
+		{desc: "checked sub uint", f: CheckedSub(uint(2)), in: uint(1), err: numeric.ErrOverflow},
+		{desc: "checked mul", f: CheckedMul(3), in: 3, out: 9},
+		{desc: "checked mul overflow", f: CheckedMul(int64(2)), in: int64(math.MaxInt64), err: numeric.ErrOverflow},
+		{desc: "checked mul min", f: CheckedMul(int64(-1)), in: int64(math.MinInt64), err: numeric.ErrOverflow},
+		{desc: "checked mul narrow", f: CheckedMul(int16(2)), in: int16(math.MaxInt16), err: numeric.ErrOverflow},
+		{desc: "checked mul uint", f: CheckedMul(uint64(2)), in: uint64(math.MaxUint64), err: numeric.ErrOverflow},
+		{desc: "checked div", f: CheckedDiv(int8(-1)), in: int8(math.MinInt8), err: numeric.ErrOverflow},
+		{desc: "checked div by zero", f: CheckedDiv(0), in: 1, err: numeric.ErrDivideByZero},
+		{desc: "checked pow", f: CheckedPow(int64(62)), in: int64(2), out: int64(1 << 62)},
+		{desc: "checked pow overflow", f: CheckedPow(int64(63)), in: int64(2), err: numeric.ErrOverflow},
+		{desc: "checked pow negative", f: CheckedPow(int64(63)), in: int64(-2), out: int64(math.MinInt64)},
+		{desc: "checked pow uint overflow", f: CheckedPow(uint8(2)), in: uint8(16), err: numeric.ErrOverflow},
+		{desc: "checked neg", f: CheckedNeg(), in: int8(math.MinInt8), err: numeric.ErrOverflow},
+		{desc: "checked neg uint", f: CheckedNeg(), in: uint(1), err: numeric.ErrOverflow},
+		{desc: "checked neg uint zero", f: CheckedNeg(), in: uint(0), out: uint(0)},
+		{desc: "checked abs", f: CheckedAbs(), in: int64(math.MinInt64), err: numeric.ErrOverflow},
+
+		{desc: "saturating add", f: SaturatingAdd(int8(100)), in: int8(100), out: int8(math.MaxInt8)},
+		{desc: "saturating add low", f: SaturatingAdd(int8(-100)), in: int8(-100), out: int8(math.MinInt8)},
+		{desc: "saturating add uint", f: SaturatingAdd(uint16(1)), in: uint16(math.MaxUint16), out: uint16(math.MaxUint16)},
+		{desc: "saturating add float", f: SaturatingAdd(math.MaxFloat64), in: math.MaxFloat64, out: math.MaxFloat64},
+		{desc: "saturating sub uint", f: SaturatingSub(uint(5)), in: uint(3), out: uint(0)},
+		{desc: "saturating sub", f: SaturatingSub(int64(1)), in: int64(math.MinInt64), out: int64(math.MinInt64)},
+		{desc: "saturating mul", f: SaturatingMul(int32(-2)), in: int32(math.MaxInt32), out: int32(math.MinInt32)},
+		{desc: "saturating mul both negative", f: SaturatingMul(int64(-2)), in: int64(math.MinInt64), out: int64(math.MaxInt64)},
+		{desc: "saturating div", f: SaturatingDiv(int8(-1)), in: int8(math.MinInt8), out: int8(math.MaxInt8)},
+		{desc: "saturating pow", f: SaturatingPow(int8(3)), in: int8(-8), out: int8(math.MinInt8)},
+		{desc: "saturating neg", f: SaturatingNeg(), in: int8(math.MinInt8), out: int8(math.MaxInt8)},
+		{desc: "saturating neg uint", f: SaturatingNeg(), in: uint8(3), out: uint8(0)},
+		{desc: "saturating abs", f: SaturatingAbs(), in: int8(math.MinInt8), out: int8(math.MaxInt8)},
+		{desc: "saturating duration", f: SaturatingAdd(time.Duration(math.MaxInt64)), in: time.Hour, out: time.Duration(math.MaxInt64)},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.f.Call(ctx, tC.in)
+			switch {
+			case tC.err == nil:
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			case tC.err.Error() == "":
+				assert.Error(t, err)
+			default:
+				assert.ErrorIs(t, err, tC.err)
+			}
+		})
+	}
+}
+
+func TestOverflowError(t *testing.T) {
+	_, err := CheckedSum().Call(ctx, int8(100), int8(100))
+	var oerr *numeric.OverflowError
+	require.True(t, errors.As(err, &oerr))
+	assert.Equal(t, numeric.Add, oerr.Op)
+	assert.Equal(t, []interface{}{int8(100), int8(100)}, oerr.Args)
+	assert.EqualError(t, err, "add overflows int8: [100 100]")
+}
+
+func TestCheckedSumCollection(t *testing.T) {
+	_, err := Int64s(ctx, []int64{math.MaxInt64, 1}).Reduce(CheckedSum())
+	assert.ErrorIs(t, err, numeric.ErrOverflow)
+
+	total, err := Int64s(ctx, []int64{1, 2, 3}).Reduce(CheckedSum())
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), total)
+}
@@ -2,6 +2,9 @@ package bifunction
 
 import (
 	"context"
+	"reflect"
+
+	"github.com/samwho/fu/internal/lift"
 )
 
 type B interface {
@@ -36,3 +39,23 @@ func (mbf *multiBiFn) Call(ctx context.Context, i interface{}, j interface{}) (i
 	}
 	return i, nil
 }
+
+// Lift adapts an ordinary two-argument func into a B. The func may take a
+// leading context.Context and may return a trailing error.
+func Lift(fn interface{}) (B, error) {
+	l, err := lift.New(fn, 2, reflect.Invalid)
+	if err != nil {
+		return nil, err
+	}
+	return New(func(ctx context.Context, i interface{}, j interface{}) (interface{}, error) {
+		return l.Call(ctx, i, j)
+	}), nil
+}
+
+func MustLift(fn interface{}) B {
+	bf, err := Lift(fn)
+	if err != nil {
+		panic(err)
+	}
+	return bf
+}
@@ -0,0 +1,33 @@
+package fu
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/numeric"
+)
+
+func BigInt() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return numeric.ToBigInt(i)
+	})
+}
+
+func BigFloat() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return numeric.ToBigFloat(i)
+	})
+}
+
+func BigRat() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return numeric.ToBigRat(i)
+	})
+}
+
+func FromBig(t reflect.Type) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return numeric.FromBig(i, t)
+	})
+}
@@ -0,0 +1,160 @@
+package fu
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/numeric"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigArithmetic(t *testing.T) {
+	a, b := big.NewInt(7), big.NewInt(2)
+
+	r, err := Sum().Call(ctx, a, b)
+	require.NoError(t, err)
+	assert.Equal(t, "9", r.(*big.Int).String())
+	assert.Equal(t, "7", a.String())
+	assert.Equal(t, "2", b.String())
+
+	r, err = NegativeSum().Call(ctx, a, b)
+	require.NoError(t, err)
+	assert.Equal(t, "5", r.(*big.Int).String())
+
+	r, err = Multiply().Call(ctx, big.NewRat(1, 3), big.NewRat(3, 4))
+	require.NoError(t, err)
+	assert.Equal(t, "1/4", r.(*big.Rat).String())
+
+	r, err = Sum().Call(ctx, big.NewFloat(1.5), big.NewFloat(2))
+	require.NoError(t, err)
+	assert.Equal(t, "3.5", r.(*big.Float).String())
+
+	r, err = Div(b).Call(ctx, a)
+	require.NoError(t, err)
+	assert.Equal(t, "3", r.(*big.Int).String())
+
+	_, err = Div(new(big.Rat)).Call(ctx, big.NewRat(1, 2))
+	assert.ErrorIs(t, err, numeric.ErrDivideByZero)
+
+	r, err = Pow(big.NewInt(100)).Call(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	assert.Equal(t, "1267650600228229401496703205376", r.(*big.Int).String())
+
+	r, err = Neg().Call(ctx, a)
+	require.NoError(t, err)
+	assert.Equal(t, "-7", r.(*big.Int).String())
+	assert.Equal(t, "7", a.String())
+
+	_, err = Sum().Call(ctx, a, big.NewRat(1, 2))
+	assert.Error(t, err)
+
+	_, err = Sum().Call(ctx, a, (*big.Int)(nil))
+	assert.ErrorIs(t, err, numeric.ErrNil)
+
+	_, err = Pow(big.NewFloat(2)).Call(ctx, big.NewFloat(2))
+	assert.Error(t, err)
+
+	inf, negInf := big.NewFloat(math.Inf(1)), big.NewFloat(math.Inf(-1))
+	for _, tc := range []struct {
+		bf   bifunction.B
+		a, b *big.Float
+	}{
+		{Sum(), inf, negInf},
+		{NegativeSum(), inf, inf},
+		{Multiply(), big.NewFloat(0), inf},
+		{Quotient(), inf, inf},
+	} {
+		_, err = tc.bf.Call(ctx, tc.a, tc.b)
+		assert.ErrorIs(t, err, numeric.ErrNaN)
+	}
+	r, err = Sum().Call(ctx, inf, inf)
+	require.NoError(t, err)
+	assert.True(t, r.(*big.Float).IsInf())
+
+	r, err = Promoted(Sum()).Call(ctx, a, 1.5)
+	require.NoError(t, err)
+	assert.Equal(t, "17/2", r.(*big.Rat).String())
+
+	r, err = Promoted(Sum()).Call(ctx, int8(1), a)
+	require.NoError(t, err)
+	assert.Equal(t, "8", r.(*big.Int).String())
+
+	r, err = Promoted(Sum()).Call(ctx, uint64(math.MaxUint64), -1)
+	require.NoError(t, err)
+	assert.Equal(t, "18446744073709551614", r.(*big.Int).String())
+}
+
+func TestBigComparisons(t *testing.T) {
+	testCases := []struct {
+		desc string
+		ok   func() (bool, error)
+		out  bool
+	}{
+		{desc: "gt int", ok: func() (bool, error) { return Gt(big.NewInt(1)).Test(ctx, big.NewInt(2)) }, out: true},
+		{desc: "lt int", ok: func() (bool, error) { return Lt(big.NewInt(1)).Test(ctx, big.NewInt(2)) }, out: false},
+		{desc: "gt rat", ok: func() (bool, error) { return Gt(big.NewRat(1, 3)).Test(ctx, big.NewRat(1, 2)) }, out: true},
+		{desc: "lt float", ok: func() (bool, error) { return Lt(big.NewFloat(1)).Test(ctx, big.NewFloat(0.5)) }, out: true},
+		{desc: "eq int", ok: func() (bool, error) {
+			return Eq(big.NewInt(3)).Test(ctx, new(big.Int).Add(big.NewInt(1), big.NewInt(2)))
+		}, out: true},
+		{desc: "eq float precision", ok: func() (bool, error) {
+			return Eq(big.NewFloat(1)).Test(ctx, new(big.Float).SetPrec(200).SetInt64(1))
+		}, out: true},
+		{desc: "neq rat", ok: func() (bool, error) { return Neq(big.NewRat(1, 2)).Test(ctx, big.NewRat(2, 4)) }, out: false},
+		{desc: "gte", ok: func() (bool, error) { return Gte(big.NewInt(2)).Test(ctx, big.NewInt(2)) }, out: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			b, err := tC.ok()
+			require.NoError(t, err)
+			assert.Equal(t, tC.out, b)
+		})
+	}
+}
+
+func TestBigConversions(t *testing.T) {
+	total, err := Int64s(ctx, []int64{math.MaxInt64, math.MaxInt64}).Map(BigInt()).Reduce(Sum())
+	require.NoError(t, err)
+	assert.Equal(t, "18446744073709551614", total.(*big.Int).String())
+
+	_, err = FromBig(reflect.TypeOf(int64(0))).Call(ctx, total)
+	assert.ErrorIs(t, err, numeric.ErrOverflow)
+
+	n, err := FromBig(reflect.TypeOf(uint64(0))).Call(ctx, total)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64-1), n)
+
+	n, err = FromBig(reflect.TypeOf(0.0)).Call(ctx, big.NewRat(1, 4))
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, n)
+
+	_, err = FromBig(reflect.TypeOf(0)).Call(ctx, big.NewRat(1, 4))
+	assert.Error(t, err)
+
+	_, err = FromBig(reflect.TypeOf(0)).Call(ctx, 1)
+	assert.Error(t, err)
+
+	r, err := BigRat().Call(ctx, 0.5)
+	require.NoError(t, err)
+	assert.Equal(t, "1/2", r.(*big.Rat).String())
+
+	f, err := BigFloat().Call(ctx, uint8(3))
+	require.NoError(t, err)
+	assert.Equal(t, "3", f.(*big.Float).String())
+
+	_, err = BigInt().Call(ctx, 1.5)
+	assert.Error(t, err)
+
+	_, err = BigInt().Call(ctx, "1")
+	assert.Error(t, err)
+
+	orig := big.NewInt(5)
+	cp, err := BigInt().Call(ctx, orig)
+	require.NoError(t, err)
+	assert.NotSame(t, orig, cp)
+}
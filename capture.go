@@ -0,0 +1,159 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/samwho/fu/function"
+)
+
+var ErrNoMatch = errors.New("no match")
+
+type CaptureError struct {
+	Line  string
+	Group string
+	Err   error
+}
+
+func (e *CaptureError) Error() string {
+	if e.Group == "" {
+		return fmt.Sprintf(`capture %q: %v`, e.Line, e.Err)
+	}
+	return fmt.Sprintf(`capture %q: group %q: %v`, e.Line, e.Group, e.Err)
+}
+
+func (e *CaptureError) Unwrap() error {
+	return e.Err
+}
+
+// Capturer turns strings into map[string]interface{} records keyed by the
+// named groups of a regular expression. It is both a function.F, which
+// performs the capture, and a predicate.P, which reports whether a line
+// matches.
+type Capturer struct {
+	re     *regexp.Regexp
+	err    error
+	coerce map[string]function.F
+	reject bool
+}
+
+// Capture compiles pattern, which must contain at least one named group such
+// as `(?P<status>\d+)`. Unnamed groups are not included in the result.
+func Capture(pattern string) *Capturer {
+	re, err := regexp.Compile(pattern)
+	if err == nil && !hasNamedGroup(re) {
+		err = fmt.Errorf(`pattern %q has no named groups`, pattern)
+	}
+	return &Capturer{re: re, err: err, coerce: map[string]function.F{}}
+}
+
+func hasNamedGroup(re *regexp.Regexp) bool {
+	for _, n := range re.SubexpNames() {
+		if n != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Capturer) with(group string, f function.F) *Capturer {
+	cp := *c
+	cp.coerce = make(map[string]function.F, len(c.coerce)+1)
+	for k, v := range c.coerce {
+		cp.coerce[k] = v
+	}
+	if c.re != nil && c.re.SubexpIndex(group) < 0 && cp.err == nil {
+		cp.err = fmt.Errorf(`pattern %q has no group %q`, c.re, group)
+	}
+	cp.coerce[group] = f
+	return &cp
+}
+
+// Coerce applies f to the text captured by group.
+func (c *Capturer) Coerce(group string, f function.F) *Capturer {
+	return c.with(group, f)
+}
+
+func (c *Capturer) Int(group string) *Capturer {
+	return c.with(group, ToInt())
+}
+
+func (c *Capturer) Float(group string) *Capturer {
+	return c.with(group, ToFloat64())
+}
+
+func (c *Capturer) Time(group string, layout string) *Capturer {
+	return c.with(group, ParseTime(layout))
+}
+
+// Reject makes Call return nil rather than an error for lines that don't
+// match, and makes Collection.Capture and Stream.Capture drop them.
+func (c *Capturer) Reject() *Capturer {
+	cp := *c
+	cp.reject = true
+	return &cp
+}
+
+func (c *Capturer) Test(ctx context.Context, i interface{}) (bool, error) {
+	if c.err != nil {
+		return false, c.err
+	}
+	s, err := asString("Capture", i)
+	if err != nil {
+		return false, err
+	}
+	return c.re.MatchString(s), nil
+}
+
+// Call returns a map of every named group. Optional groups that took no part
+// in the match are nil and are not coerced.
+func (c *Capturer) Call(ctx context.Context, i interface{}) (interface{}, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	s, err := asString("Capture", i)
+	if err != nil {
+		return nil, err
+	}
+	idx := c.re.FindStringSubmatchIndex(s)
+	if idx == nil {
+		if c.reject {
+			return nil, nil
+		}
+		return nil, &CaptureError{Line: s, Err: ErrNoMatch}
+	}
+	m := make(map[string]interface{})
+	for g, n := range c.re.SubexpNames() {
+		if n == "" {
+			continue
+		}
+		if idx[2*g] < 0 {
+			m[n] = nil
+			continue
+		}
+		var v interface{} = s[idx[2*g]:idx[2*g+1]]
+		if f, ok := c.coerce[n]; ok {
+			if v, err = f.Call(ctx, v); err != nil {
+				return nil, &CaptureError{Line: s, Group: n, Err: err}
+			}
+		}
+		m[n] = v
+	}
+	return m, nil
+}
+
+func (c *Collection) Capture(cp *Capturer) *Collection {
+	if cp.reject {
+		c.Select(cp)
+	}
+	return c.Map(cp)
+}
+
+func (s *Stream) Capture(cp *Capturer) *Stream {
+	if cp.reject {
+		s.Select(cp)
+	}
+	return s.Map(cp)
+}
@@ -0,0 +1,95 @@
+package fu
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const accessLog = `(?P<ip>\S+) \[(?P<at>[^\]]+)\] "(?P<method>[A-Z]+) (?P<path>\S+)" (?P<status>\d+) (?P<ms>[\d.]+)(?: (?P<user>\w+))?`
+
+func TestCapture(t *testing.T) {
+	t.Parallel()
+
+	line := `10.0.0.1 [2020-01-02T03:04:05Z] "GET /index.html" 200 1.5`
+	cp := Capture(accessLog).Int("status").Float("ms").Time("at", time.RFC3339)
+
+	testCases := []struct {
+		desc        string
+		cp          *Capturer
+		in          interface{}
+		out         interface{}
+		expectedErr bool
+	}{
+		{
+			desc: "coerced",
+			cp:   cp,
+			in:   line,
+			out: map[string]interface{}{
+				"ip":     "10.0.0.1",
+				"at":     time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				"method": "GET",
+				"path":   "/index.html",
+				"status": 200,
+				"ms":     1.5,
+				"user":   nil,
+			},
+		},
+		{
+			desc: "uncoerced",
+			cp:   Capture(`(?P<k>\w+)=(?P<v>\w+)`),
+			in:   "a=1",
+			out:  map[string]interface{}{"k": "a", "v": "1"},
+		},
+		{desc: "no match", cp: cp, in: "garbage", expectedErr: true},
+		{desc: "no match rejected", cp: cp.Reject(), in: "garbage", out: nil},
+		{desc: "coercion fails", cp: Capture(`(?P<n>\w+)`).Int("n"), in: "abc", expectedErr: true},
+		{desc: "not a string", cp: cp, in: 1, expectedErr: true},
+		{desc: "bad pattern", cp: Capture(`(`), in: "x", expectedErr: true},
+		{desc: "no named groups", cp: Capture(`(\w+)`), in: "x", expectedErr: true},
+		{desc: "unknown group", cp: Capture(`(?P<a>\w+)`).Int("b"), in: "x", expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.cp.Call(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestCaptureErrors(t *testing.T) {
+	_, err := Capture(`(?P<n>\d+)`).Call(ctx, "abc")
+	assert.True(t, errors.Is(err, ErrNoMatch))
+
+	_, err = Capture(`(?P<n>\w+)`).Int("n").Call(ctx, "abc")
+	var ce *CaptureError
+	require.True(t, errors.As(err, &ce))
+	assert.Equal(t, "n", ce.Group)
+	assert.Equal(t, "abc", ce.Line)
+}
+
+func TestCollectionCapture(t *testing.T) {
+	lines := []string{"a=1", "# comment", "b=2"}
+	cp := Capture(`^(?P<k>\w+)=(?P<v>\d+)$`).Int("v")
+
+	result, err := Strings(ctx, lines).Capture(cp.Reject()).Map(Field("v")).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, result)
+
+	_, err = Strings(ctx, lines).Capture(cp).Interfaces()
+	assert.True(t, errors.Is(err, ErrNoMatch))
+
+	result, err = Strings(ctx, lines).Lazy().Capture(cp.Reject()).Take(1).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"k": "a", "v": 1}}, result)
+}
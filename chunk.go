@@ -0,0 +1,168 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+	"github.com/samwho/fu/reducer"
+)
+
+func Chunk(ctx context.Context, is []interface{}, n int) ([]interface{}, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf(`chunk size must be positive, got %d`, n)
+	}
+	ret := make([]interface{}, 0, (len(is)+n-1)/n)
+	for start := 0; start < len(is); start += n {
+		end := start + n
+		if end > len(is) {
+			end = len(is)
+		}
+		ret = append(ret, is[start:end:end])
+	}
+	return ret, nil
+}
+
+func Sliding(ctx context.Context, is []interface{}, size int, step int) ([]interface{}, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf(`window size must be positive, got %d`, size)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf(`window step must be positive, got %d`, step)
+	}
+	var ret []interface{}
+	for start := 0; start+size <= len(is); start += step {
+		end := start + size
+		ret = append(ret, is[start:end:end])
+	}
+	return ret, nil
+}
+
+func ChunkBy(ctx context.Context, is []interface{}, f function.F) ([]interface{}, error) {
+	var ret []interface{}
+	var prev interface{}
+	start := 0
+	for idx, i := range is {
+		k, err := f.Call(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		if idx > 0 {
+			same, err := Eq(prev).Test(ctx, k)
+			if err != nil {
+				return nil, err
+			}
+			if !same {
+				ret = append(ret, is[start:idx:idx])
+				start = idx
+			}
+		}
+		prev = k
+	}
+	if start < len(is) {
+		ret = append(ret, is[start:len(is):len(is)])
+	}
+	return ret, nil
+}
+
+func Partition(ctx context.Context, is []interface{}, p predicate.P) ([]interface{}, []interface{}, error) {
+	var matching, rest []interface{}
+	for _, i := range is {
+		b, err := p.Test(ctx, i)
+		if err != nil {
+			return nil, nil, err
+		}
+		if b {
+			matching = append(matching, i)
+		} else {
+			rest = append(rest, i)
+		}
+	}
+	return matching, rest, nil
+}
+
+func PartitionFn(ctx context.Context, is []interface{}, p predicate.Fn) ([]interface{}, []interface{}, error) {
+	return Partition(ctx, is, predicate.New(p))
+}
+
+func ReduceEach(bf bifunction.B) function.F {
+	r := reducer.New(bf)
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		is, ok := i.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf(`cannot reduce non-slice: %v`, i)
+		}
+		return r.Reduce(ctx, is)
+	})
+}
+
+func Mean() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		is, ok := i.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf(`cannot average non-slice: %v`, i)
+		}
+		if len(is) == 0 {
+			return nil, errors.New("cannot average empty slice")
+		}
+		sum, err := Reduce(ctx, is, Sum())
+		if err != nil {
+			return nil, err
+		}
+		v := reflect.ValueOf(sum)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(v.Int()) / float64(len(is)), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return float64(v.Uint()) / float64(len(is)), nil
+		case reflect.Float32, reflect.Float64:
+			return v.Float() / float64(len(is)), nil
+		default:
+			return nil, fmt.Errorf(`cannot average non-numeric: %v`, sum)
+		}
+	})
+}
+
+func (c *Collection) Chunk(n int) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = Chunk(c.ctx, c.is, n)
+	return c
+}
+
+func (c *Collection) Sliding(size int, step int) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = Sliding(c.ctx, c.is, size, step)
+	return c
+}
+
+func (c *Collection) SlidingReduce(size int, step int, bf bifunction.B) *Collection {
+	return c.Sliding(size, step).Map(ReduceEach(bf))
+}
+
+func (c *Collection) ChunkBy(f function.F) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = ChunkBy(c.ctx, c.is, f)
+	return c
+}
+
+func (c *Collection) PartitionFn(p predicate.Fn) (*Collection, *Collection) {
+	return c.Partition(predicate.New(p))
+}
+
+func (c *Collection) Partition(p predicate.P) (*Collection, *Collection) {
+	if c.err != nil {
+		return c, &Collection{c.ctx, nil, c.err}
+	}
+	matching, rest, err := Partition(c.ctx, c.is, p)
+	return &Collection{c.ctx, matching, err}, &Collection{c.ctx, rest, err}
+}
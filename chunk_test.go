@@ -0,0 +1,132 @@
+package fu
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samwho/fu/bifunction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mod = bifunction.New(func(ctx context.Context, i interface{}, j interface{}) (interface{}, error) {
+	return i.(int) % j.(int), nil
+})
+
+func TestChunking(t *testing.T) {
+	t.Parallel()
+
+	in := []interface{}{1, 2, 3, 4, 5}
+	testCases := []struct {
+		desc        string
+		f           func() ([]interface{}, error)
+		out         []interface{}
+		expectedErr bool
+	}{
+		{
+			desc: "chunk",
+			f:    func() ([]interface{}, error) { return Chunk(ctx, in, 2) },
+			out:  []interface{}{[]interface{}{1, 2}, []interface{}{3, 4}, []interface{}{5}},
+		},
+		{
+			desc:        "chunk zero",
+			f:           func() ([]interface{}, error) { return Chunk(ctx, in, 0) },
+			expectedErr: true,
+		},
+		{
+			desc: "sliding",
+			f:    func() ([]interface{}, error) { return Sliding(ctx, in, 3, 1) },
+			out:  []interface{}{[]interface{}{1, 2, 3}, []interface{}{2, 3, 4}, []interface{}{3, 4, 5}},
+		},
+		{
+			desc: "sliding with step",
+			f:    func() ([]interface{}, error) { return Sliding(ctx, in, 2, 2) },
+			out:  []interface{}{[]interface{}{1, 2}, []interface{}{3, 4}},
+		},
+		{
+			desc: "sliding too big",
+			f:    func() ([]interface{}, error) { return Sliding(ctx, in, 6, 1) },
+			out:  nil,
+		},
+		{
+			desc:        "sliding zero step",
+			f:           func() ([]interface{}, error) { return Sliding(ctx, in, 2, 0) },
+			expectedErr: true,
+		},
+		{
+			desc: "chunk by",
+			f: func() ([]interface{}, error) {
+				return ChunkBy(ctx, []interface{}{1, 3, 2, 4, 5}, ApplyEnd(mod, 2))
+			},
+			out: []interface{}{[]interface{}{1, 3}, []interface{}{2, 4}, []interface{}{5}},
+		},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.f()
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestPartition(t *testing.T) {
+	matching, rest, err := Partition(ctx, []interface{}{0, 1, 2, 3}, Gt(1))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 3}, matching)
+	assert.Equal(t, []interface{}{0, 1}, rest)
+
+	_, _, err = PartitionFn(ctx, []interface{}{0}, func(ctx context.Context, i interface{}) (bool, error) {
+		return false, assert.AnError
+	})
+	assert.Error(t, err)
+}
+
+func TestCollectionChunk(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3, 4, 5}).Chunk(2).Map(ReduceEach(Sum())).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 7, 5}, result)
+}
+
+func TestCollectionMovingAverage(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3, 4, 5}).Sliding(3, 1).Map(Mean()).Float64s()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2, 3, 4}, result)
+}
+
+func TestCollectionSlidingReduce(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3, 4}).SlidingReduce(2, 1, Sum()).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 5, 7}, result)
+}
+
+func TestCollectionChunkBy(t *testing.T) {
+	result, err := Strings(ctx, []string{"a", "a", "b", "a"}).ChunkBy(Identity()).Map(Len()).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1, 1}, result)
+}
+
+func TestCollectionPartition(t *testing.T) {
+	matching, rest := Ints(ctx, []int{1, 2, 3, 4}).Partition(Gt(2))
+
+	m, err := matching.Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, m)
+
+	r, err := rest.Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, r)
+}
+
+func TestCollectionPartitionAfterErr(t *testing.T) {
+	matching, rest := Strings(ctx, []string{"a"}).Map(Add(1)).Partition(Gt(2))
+	assert.Error(t, matching.Error())
+	assert.Error(t, rest.Error())
+}
@@ -0,0 +1,351 @@
+// Command fu applies fu pipelines to records read from JSON Lines, CSV or
+// plain text, in the manner of jq:
+//
+//	fu -select 'status >= 500' -map 'path' -o table access.jsonl
+//	fu -i csv -group-by 'city' -sort-desc 'key' people.csv
+//	fu -i lines -map 'it' -reduce 'join(",")' names.txt
+//
+// Stages run in the order their flags are given. Expressions use the syntax
+// of fu.Expr, and reduce names a function in fu.NewRegistry, optionally
+// followed by JSON arguments in parentheses.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/samwho/fu"
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/internal/hashkey"
+)
+
+func main() {
+	err := run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "fu: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type stage struct {
+	kind string
+	arg  string
+}
+
+type stageFlag struct {
+	kind   string
+	stages *[]stage
+}
+
+func (f *stageFlag) String() string {
+	return ""
+}
+
+func (f *stageFlag) Set(s string) error {
+	*f.stages = append(*f.stages, stage{f.kind, s})
+	return nil
+}
+
+var stageKinds = []struct {
+	kind  string
+	usage string
+}{
+	{"map", "replace each record with the value of `expr`"},
+	{"select", "keep records for which `expr` is true"},
+	{"reject", "drop records for which `expr` is true"},
+	{"group-by", "group records by `expr` into {\"key\": ..., \"values\": [...]}"},
+	{"sort", "sort records by `expr`, ascending"},
+	{"sort-desc", "sort records by `expr`, descending"},
+	{"reduce", "reduce records with the registered bifunction `name`, such as sum or join(\",\")"},
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("fu", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	in := fs.String("i", "jsonl", "input `format`: jsonl, csv or lines")
+	out := fs.String("o", "jsonl", "output `format`: jsonl, csv or table")
+	var stages []stage
+	for _, k := range stageKinds {
+		fs.Var(&stageFlag{k.kind, &stages}, k.kind, k.usage)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: fu [flags] [file ...]\n\nReads stdin if no files are given.\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	read, ok := readers[*in]
+	if !ok {
+		return fmt.Errorf(`unknown input format %q`, *in)
+	}
+	write, ok := writers[*out]
+	if !ok {
+		return fmt.Errorf(`unknown output format %q`, *out)
+	}
+	steps, err := compile(stages)
+	if err != nil {
+		return err
+	}
+
+	var is []interface{}
+	if fs.NArg() == 0 {
+		if is, err = read(ctx, stdin); err != nil {
+			return fmt.Errorf(`stdin: %w`, err)
+		}
+	}
+	for _, name := range fs.Args() {
+		rs, err := readFile(ctx, name, stdin, read)
+		if err != nil {
+			return fmt.Errorf(`%s: %w`, name, err)
+		}
+		is = append(is, rs...)
+	}
+
+	for _, step := range steps {
+		if is, err = step(ctx, is); err != nil {
+			return err
+		}
+	}
+	return write(stdout, is)
+}
+
+// readFile reads the named file, or stdin if name is "-".
+func readFile(ctx context.Context, name string, stdin io.Reader, read func(context.Context, io.Reader) ([]interface{}, error)) ([]interface{}, error) {
+	if name == "-" {
+		return read(ctx, stdin)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return read(ctx, f)
+}
+
+type step func(ctx context.Context, is []interface{}) ([]interface{}, error)
+
+func compile(stages []stage) ([]step, error) {
+	registry := fu.NewRegistry()
+	steps := make([]step, 0, len(stages))
+	for _, s := range stages {
+		if s.kind == "reduce" {
+			bf, err := lookupReducer(registry, s.arg)
+			if err != nil {
+				return nil, fmt.Errorf(`-reduce %s: %w`, s.arg, err)
+			}
+			steps = append(steps, func(ctx context.Context, is []interface{}) ([]interface{}, error) {
+				v, err := fu.Reduce(ctx, is, bf)
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{v}, nil
+			})
+			continue
+		}
+
+		e, err := fu.Expr(s.arg)
+		if err != nil {
+			return nil, fmt.Errorf(`-%s: %w`, s.kind, err)
+		}
+		switch s.kind {
+		case "map":
+			steps = append(steps, func(ctx context.Context, is []interface{}) ([]interface{}, error) {
+				return fu.Map(ctx, is, e)
+			})
+		case "select":
+			steps = append(steps, func(ctx context.Context, is []interface{}) ([]interface{}, error) {
+				return fu.Select(ctx, is, e)
+			})
+		case "reject":
+			steps = append(steps, func(ctx context.Context, is []interface{}) ([]interface{}, error) {
+				return fu.Reject(ctx, is, e)
+			})
+		case "group-by":
+			steps = append(steps, func(ctx context.Context, is []interface{}) ([]interface{}, error) {
+				return groupBy(ctx, is, e)
+			})
+		case "sort", "sort-desc":
+			cmp := fu.CompareBy(e, fu.Compare())
+			if s.kind == "sort-desc" {
+				cmp = fu.Reverse(cmp)
+			}
+			steps = append(steps, func(ctx context.Context, is []interface{}) ([]interface{}, error) {
+				return fu.Sort(ctx, is, cmp)
+			})
+		}
+	}
+	return steps, nil
+}
+
+// lookupReducer parses `name` or `name(args)`, in which args are JSON values.
+func lookupReducer(r *fu.Registry, s string) (bifunction.B, error) {
+	name, args := strings.TrimSpace(s), []interface{}(nil)
+	if n := strings.IndexByte(s, '('); n >= 0 && strings.HasSuffix(s, ")") {
+		name = strings.TrimSpace(s[:n])
+		d := json.NewDecoder(strings.NewReader("[" + s[n+1:len(s)-1] + "]"))
+		d.UseNumber()
+		if err := d.Decode(&args); err != nil {
+			return nil, fmt.Errorf(`arguments: %w`, err)
+		}
+	}
+	f, err := r.Lookup(name, args...)
+	if err != nil {
+		return nil, err
+	}
+	bf, ok := f.(bifunction.B)
+	if !ok {
+		return nil, fmt.Errorf(`%q is not a bifunction`, name)
+	}
+	return bf, nil
+}
+
+// groupBy keeps groups in order of first appearance.
+func groupBy(ctx context.Context, is []interface{}, e *fu.Expression) ([]interface{}, error) {
+	index := make(map[interface{}]int)
+	var groups []interface{}
+	for _, i := range is {
+		k, err := e.Call(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		if err := hashkey.Check(k); err != nil {
+			return nil, fmt.Errorf(`-group-by %s: cannot group by: %w`, e, err)
+		}
+		n, ok := index[k]
+		if !ok {
+			n = len(groups)
+			index[k] = n
+			groups = append(groups, map[string]interface{}{"key": k, "values": []interface{}{}})
+		}
+		g := groups[n].(map[string]interface{})
+		g["values"] = append(g["values"].([]interface{}), i)
+	}
+	return groups, nil
+}
+
+var readers = map[string]func(context.Context, io.Reader) ([]interface{}, error){
+	"jsonl": func(ctx context.Context, r io.Reader) ([]interface{}, error) {
+		return fu.FromJSONLines(ctx, r, nil).Interfaces()
+	},
+	"csv": func(ctx context.Context, r io.Reader) ([]interface{}, error) {
+		return fu.FromCSV(ctx, r).MapFn(cells).Interfaces()
+	},
+	"lines": func(ctx context.Context, r io.Reader) ([]interface{}, error) {
+		return fu.FromLines(ctx, r).Interfaces()
+	},
+}
+
+// cells converts the values of a CSV record that parse as numbers to ints
+// or float64s, except those such as "007" that would not survive the round
+// trip.
+func cells(ctx context.Context, i interface{}) (interface{}, error) {
+	m := i.(map[string]interface{})
+	for k, v := range m {
+		s := v.(string)
+		if n, err := strconv.Atoi(s); err == nil && strconv.Itoa(n) == s {
+			m[k] = n
+		} else if f, err := strconv.ParseFloat(s, 64); err == nil && strings.ContainsAny(s, ".eE") {
+			m[k] = f
+		}
+	}
+	return m, nil
+}
+
+var writers = map[string]func(io.Writer, []interface{}) error{
+	"jsonl": writeJSONLines,
+	"csv":   writeCSV,
+	"table": writeTable,
+}
+
+func writeJSONLines(w io.Writer, is []interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, i := range is {
+		if err := enc.Encode(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, is []interface{}) error {
+	cw := csv.NewWriter(w)
+	for _, row := range rows(is) {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeTable(w io.Writer, is []interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range rows(is) {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// rows returns a header and a row per record. The columns of records that
+// are JSON objects are the sorted union of their keys, and anything else is
+// a single column called "value".
+func rows(is []interface{}) [][]string {
+	seen := make(map[string]bool)
+	var cols []string
+	for _, i := range is {
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{"value": i}
+		}
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				cols = append(cols, k)
+			}
+		}
+	}
+	sort.Strings(cols)
+
+	ret := [][]string{cols}
+	for _, i := range is {
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			m = map[string]interface{}{"value": i}
+		}
+		row := make([]string, 0, len(cols))
+		for _, c := range cols {
+			row = append(row, format(m[c]))
+		}
+		ret = append(ret, row)
+	}
+	return ret
+}
+
+func format(i interface{}) string {
+	switch v := i.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(bs)
+	}
+	return fmt.Sprint(i)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const people = `{"name": "Ann", "age": 42, "city": "Leeds"}
+{"name": "Bob", "age": 25, "city": "York"}
+
+{"name": "Cat", "age": 35, "city": "Leeds"}
+`
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc        string
+		args        []string
+		in          string
+		out         string
+		expectedErr bool
+	}{
+		{
+			desc: "passthrough",
+			in:   people,
+			out: `{"age":42,"city":"Leeds","name":"Ann"}
+{"age":25,"city":"York","name":"Bob"}
+{"age":35,"city":"Leeds","name":"Cat"}
+`,
+		},
+		{
+			desc: "select and map",
+			args: []string{"-select", "age > 30", "-map", "name"},
+			in:   people,
+			out:  "\"Ann\"\n\"Cat\"\n",
+		},
+		{
+			desc: "stages run in flag order",
+			args: []string{"-map", "age", "-reject", "it < 30", "-sort-desc", "it", "-reduce", "sum"},
+			in:   people,
+			out:  "77\n",
+		},
+		{
+			desc: "group by",
+			args: []string{"-group-by", "city", "-map", "key", "-sort", "it", "-o", "csv"},
+			in:   people,
+			out:  "value\nLeeds\nYork\n",
+		},
+		{
+			desc: "csv to table",
+			args: []string{"-i", "csv", "-select", "age >= 30", "-o", "table"},
+			in:   "name,age,zip\nAnn,42,007\nBob,25,123\nDan,31.5,9\n",
+			out: `age   name  zip
+42    Ann   007
+31.5  Dan   9
+`,
+		},
+		{
+			desc: "lines with reduce arguments",
+			args: []string{"-i", "lines", "-reject", `it == ""`, "-reduce", `join(", ")`},
+			in:   "a\n\nb\n",
+			out:  "\"a, b\"\n",
+		},
+		{
+			desc: "dash reads stdin",
+			args: []string{"-i", "lines", "-"},
+			in:   "a\nb\n",
+			out:  "\"a\"\n\"b\"\n",
+		},
+		{
+			desc:        "missing file",
+			args:        []string{"does-not-exist.jsonl"},
+			expectedErr: true,
+		},
+		{
+			desc:        "bad json reports line",
+			in:          "{}\n\n{\n",
+			expectedErr: true,
+		},
+		{
+			desc:        "bad expression",
+			args:        []string{"-select", "age >"},
+			expectedErr: true,
+		},
+		{
+			desc:        "unknown reducer",
+			args:        []string{"-reduce", "nope"},
+			expectedErr: true,
+		},
+		{
+			desc:        "reducer that is not a bifunction",
+			args:        []string{"-reduce", "add(1)"},
+			expectedErr: true,
+		},
+		{
+			desc:        "unknown format",
+			args:        []string{"-o", "xml"},
+			expectedErr: true,
+		},
+		{
+			desc:        "group by object",
+			args:        []string{"-group-by", "it"},
+			in:          people,
+			expectedErr: true,
+		},
+		{
+			desc:        "group by list",
+			args:        []string{"-group-by", "[name, [age]]"},
+			in:          people,
+			expectedErr: true,
+		},
+		{
+			desc:        "run time error",
+			args:        []string{"-map", "missing"},
+			in:          people,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			var out, errOut bytes.Buffer
+			err := run(context.Background(), tc.args, strings.NewReader(tc.in), &out, &errOut)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.out, out.String())
+		})
+	}
+}
+
+func TestRunLineNumbers(t *testing.T) {
+	t.Parallel()
+
+	err := run(context.Background(), nil, strings.NewReader("{}\n\n{\n"), &bytes.Buffer{}, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
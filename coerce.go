@@ -0,0 +1,376 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/internal/lift"
+	"github.com/samwho/fu/numeric"
+)
+
+// Coercion controls how forgiving the To* functions are. Strict only accepts
+// conversions that lose nothing; Lossy additionally truncates floats,
+// discards zero imaginary parts and maps bools to 0 and 1 and back, and
+// ParseStrings parses numbers and bools out of strings.
+type Coercion int
+
+const (
+	Lossy Coercion = 1 << iota
+	ParseStrings
+)
+
+const (
+	Strict          Coercion = 0
+	DefaultCoercion          = ParseStrings
+)
+
+var ErrCoerce = errors.New("cannot coerce")
+
+type CoercionError struct {
+	Value interface{}
+	To    string
+	Err   error
+}
+
+func (e *CoercionError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf(`%v %T %v to %s`, ErrCoerce, e.Value, e.Value, e.To)
+	}
+	return fmt.Sprintf(`%v %T %v to %s: %v`, ErrCoerce, e.Value, e.Value, e.To, e.Err)
+}
+
+func (e *CoercionError) Unwrap() error {
+	return e.Err
+}
+
+func (e *CoercionError) Is(target error) bool {
+	return target == ErrCoerce
+}
+
+// ElementError records which element of a collection a terminal such as
+// IntsCoerced failed on.
+type ElementError struct {
+	Index int
+	Value interface{}
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf(`element %d (%v): %v`, e.Index, e.Value, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+func (co Coercion) has(flag Coercion) bool {
+	return co&flag != 0
+}
+
+func ToInt() function.F {
+	return DefaultCoercion.ToInt()
+}
+
+func ToInt64() function.F {
+	return DefaultCoercion.ToInt64()
+}
+
+func ToFloat64() function.F {
+	return DefaultCoercion.ToFloat64()
+}
+
+func ToString() function.F {
+	return DefaultCoercion.ToString()
+}
+
+func ToBool() function.F {
+	return DefaultCoercion.ToBool()
+}
+
+func (co Coercion) ToInt() function.F {
+	return co.integer(reflect.TypeOf(int(0)))
+}
+
+func (co Coercion) ToInt64() function.F {
+	return co.integer(reflect.TypeOf(int64(0)))
+}
+
+func (co Coercion) integer(t reflect.Type) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		r, err := co.toInteger(i, t)
+		if err != nil || r == nil {
+			return nil, &CoercionError{i, t.String(), err}
+		}
+		return r, nil
+	})
+}
+
+// toInteger and toFloat64 return a nil result without an error when co
+// doesn't permit converting i at all.
+func (co Coercion) toInteger(i interface{}, t reflect.Type) (interface{}, error) {
+	if i == nil {
+		return nil, numeric.ErrNil
+	}
+	if numeric.IsBig(i) {
+		if co.has(Lossy) {
+			i = truncBig(i)
+		}
+		return numeric.FromBig(i, t)
+	}
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.String:
+		if !co.has(ParseStrings) {
+			return nil, nil
+		}
+		s := strings.TrimSpace(v.String())
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return co.toInteger(n, t)
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return co.toInteger(f, t)
+	case reflect.Bool:
+		if !co.has(Lossy) {
+			return nil, nil
+		}
+		if v.Bool() {
+			return reflect.ValueOf(1).Convert(t).Interface(), nil
+		}
+		return reflect.Zero(t).Interface(), nil
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		if imag(c) != 0 || !co.has(Lossy) {
+			return nil, nil
+		}
+		return co.toInteger(real(c), t)
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, nil
+		}
+		if co.has(Lossy) {
+			i = math.Trunc(f)
+		}
+	}
+	r, err := lift.Convert(i, t)
+	if err != nil {
+		return nil, err
+	}
+	return r.Interface(), nil
+}
+
+func truncBig(i interface{}) interface{} {
+	switch b := i.(type) {
+	case *big.Float:
+		if b != nil && !b.IsInf() {
+			n, _ := b.Int(nil)
+			return n
+		}
+	case *big.Rat:
+		if b != nil {
+			return new(big.Int).Quo(b.Num(), b.Denom())
+		}
+	}
+	return i
+}
+
+func (co Coercion) ToFloat64() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		r, err := co.toFloat64(i)
+		if err != nil || r == nil {
+			return nil, &CoercionError{i, "float64", err}
+		}
+		return r, nil
+	})
+}
+
+func (co Coercion) toFloat64(i interface{}) (interface{}, error) {
+	if i == nil {
+		return nil, numeric.ErrNil
+	}
+	if numeric.IsBig(i) {
+		return numeric.FromBig(i, reflect.TypeOf(float64(0)))
+	}
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.String:
+		if !co.has(ParseStrings) {
+			return nil, nil
+		}
+		return strconv.ParseFloat(strings.TrimSpace(v.String()), 64)
+	case reflect.Bool:
+		if !co.has(Lossy) {
+			return nil, nil
+		}
+		if v.Bool() {
+			return 1.0, nil
+		}
+		return 0.0, nil
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		if imag(c) != 0 || !co.has(Lossy) {
+			return nil, nil
+		}
+		return real(c), nil
+	}
+	if co.has(Lossy) {
+		return numeric.ToFloat64(i)
+	}
+	r, err := lift.Convert(i, reflect.TypeOf(float64(0)))
+	if err != nil {
+		return nil, err
+	}
+	return r.Interface(), nil
+}
+
+// ToString uses String methods where they exist and formats numbers and bools.
+// Other values, such as structs, are only formatted with %v if co is Lossy.
+func (co Coercion) ToString() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		switch s := i.(type) {
+		case nil:
+			return nil, &CoercionError{i, "string", numeric.ErrNil}
+		case string:
+			return s, nil
+		case []byte:
+			return string(s), nil
+		case *big.Float:
+			if s != nil {
+				return s.Text('g', -1), nil
+			}
+		case fmt.Stringer:
+			return s.String(), nil
+		case error:
+			return s.Error(), nil
+		}
+		v := reflect.ValueOf(i)
+		switch v.Kind() {
+		case reflect.String:
+			return v.String(), nil
+		case reflect.Bool:
+			return strconv.FormatBool(v.Bool()), nil
+		}
+		if numeric.IsNumeric(i) || co.has(Lossy) {
+			return fmt.Sprintf("%v", i), nil
+		}
+		return nil, &CoercionError{i, "string", nil}
+	})
+}
+
+// ToBool accepts bools, the strings understood by strconv.ParseBool if co
+// parses strings, and the numbers 0 and 1. Lossy treats any other number as
+// true.
+func (co Coercion) ToBool() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		if i == nil {
+			return nil, &CoercionError{i, "bool", numeric.ErrNil}
+		}
+		v := reflect.ValueOf(i)
+		switch v.Kind() {
+		case reflect.Bool:
+			return v.Bool(), nil
+		case reflect.String:
+			if co.has(ParseStrings) {
+				b, err := strconv.ParseBool(strings.TrimSpace(v.String()))
+				if err != nil {
+					return nil, &CoercionError{i, "bool", err}
+				}
+				return b, nil
+			}
+		default:
+			if numeric.IsNumeric(i) {
+				c, err := numeric.Compare(i, reflect.Zero(v.Type()).Interface())
+				if err != nil {
+					return nil, &CoercionError{i, "bool", err}
+				}
+				if c == 0 {
+					return false, nil
+				}
+				if co.has(Lossy) {
+					return true, nil
+				}
+				if one, err := co.toFloat64(i); err == nil && one == 1.0 {
+					return true, nil
+				}
+			}
+		}
+		return nil, &CoercionError{i, "bool", nil}
+	})
+}
+
+// ParseTime parses strings with layout, as time.Parse does, and passes
+// time.Time values through unchanged.
+func ParseTime(layout string) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		if t, ok := i.(time.Time); ok {
+			return t, nil
+		}
+		s, err := asString("ParseTime", i)
+		if err != nil {
+			return nil, &CoercionError{i, "time.Time", err}
+		}
+		t, err := time.Parse(layout, strings.TrimSpace(s))
+		if err != nil {
+			return nil, &CoercionError{i, "time.Time", err}
+		}
+		return t, nil
+	})
+}
+
+// Coerce applies f to every element, reporting the index and value of the
+// first element that fails with an *ElementError.
+func (c *Collection) Coerce(f function.F) *Collection {
+	if c.err != nil {
+		return c
+	}
+	is := make([]interface{}, 0, len(c.is))
+	for n, i := range c.is {
+		r, err := f.Call(c.ctx, i)
+		if err != nil {
+			c.err = &ElementError{n, i, err}
+			return c
+		}
+		is = append(is, r)
+	}
+	c.is = is
+	return c
+}
+
+func (c *Collection) IntsCoerced() ([]int, error) {
+	return c.Coerce(ToInt()).Ints()
+}
+
+func (c *Collection) Int64sCoerced() ([]int64, error) {
+	return c.Coerce(ToInt64()).Int64s()
+}
+
+func (c *Collection) Float64sCoerced() ([]float64, error) {
+	return c.Coerce(ToFloat64()).Float64s()
+}
+
+func (c *Collection) StringsCoerced() ([]string, error) {
+	return c.Coerce(ToString()).Strings()
+}
+
+func (c *Collection) BoolsCoerced() ([]bool, error) {
+	c.Coerce(ToBool())
+	if c.err != nil {
+		return nil, c.err
+	}
+	ret := make([]bool, 0, len(c.is))
+	for _, i := range c.is {
+		ret = append(ret, i.(bool))
+	}
+	return ret, nil
+}
@@ -0,0 +1,134 @@
+package fu
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/samwho/fu/function"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoercions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc        string
+		f           function.F
+		in          interface{}
+		out         interface{}
+		expectedErr bool
+	}{
+		{desc: "int from int8", f: ToInt(), in: int8(-3), out: -3},
+		{desc: "int from uint64", f: ToInt(), in: uint64(7), out: 7},
+		{desc: "int from uint64 overflow", f: ToInt(), in: uint64(math.MaxUint64), expectedErr: true},
+		{desc: "int from integral float", f: ToInt(), in: 3.0, out: 3},
+		{desc: "int from fractional float", f: ToInt(), in: 3.5, expectedErr: true},
+		{desc: "int from fractional float lossy", f: Lossy.ToInt(), in: -3.5, out: -3},
+		{desc: "int from NaN lossy", f: Lossy.ToInt(), in: math.NaN(), expectedErr: true},
+		{desc: "int from string", f: ToInt(), in: " 42 ", out: 42},
+		{desc: "int from float string", f: ToInt(), in: "1e3", out: 1000},
+		{desc: "int from fractional string", f: ToInt(), in: "1.5", expectedErr: true},
+		{desc: "int from fractional string lossy", f: (Lossy | ParseStrings).ToInt(), in: "1.5", out: 1},
+		{desc: "int from string strict", f: Strict.ToInt(), in: "42", expectedErr: true},
+		{desc: "int from garbage", f: ToInt(), in: "abc", expectedErr: true},
+		{desc: "int from bool", f: ToInt(), in: true, expectedErr: true},
+		{desc: "int from bool lossy", f: Lossy.ToInt(), in: true, out: 1},
+		{desc: "int from big int", f: ToInt(), in: big.NewInt(5), out: 5},
+		{desc: "int from big rat lossy", f: Lossy.ToInt(), in: big.NewRat(7, 2), out: 3},
+		{desc: "int from big rat", f: ToInt(), in: big.NewRat(7, 2), expectedErr: true},
+		{desc: "int from nil", f: ToInt(), in: nil, expectedErr: true},
+		{desc: "int64 from int", f: ToInt64(), in: 5, out: int64(5)},
+		{desc: "int64 from duration", f: ToInt64(), in: time.Second, out: int64(time.Second)},
+
+		{desc: "float from int", f: ToFloat64(), in: 2, out: 2.0},
+		{desc: "float from float32", f: ToFloat64(), in: float32(0.5), out: 0.5},
+		{desc: "float from huge int", f: ToFloat64(), in: int64(1<<53 + 1), expectedErr: true},
+		{desc: "float from huge int lossy", f: Lossy.ToFloat64(), in: int64(1<<53 + 1), out: float64(1 << 53)},
+		{desc: "float from string", f: ToFloat64(), in: "2.5", out: 2.5},
+		{desc: "float from string strict", f: Strict.ToFloat64(), in: "2.5", expectedErr: true},
+		{desc: "float from complex", f: ToFloat64(), in: complex(1, 0), expectedErr: true},
+		{desc: "float from complex lossy", f: Lossy.ToFloat64(), in: complex(1, 0), out: 1.0},
+		{desc: "float from big", f: ToFloat64(), in: big.NewRat(1, 4), out: 0.25},
+
+		{desc: "string from string", f: ToString(), in: "a", out: "a"},
+		{desc: "string from named", f: ToString(), in: stringName("b"), out: "b"},
+		{desc: "string from bytes", f: ToString(), in: []byte("c"), out: "c"},
+		{desc: "string from int", f: ToString(), in: 12, out: "12"},
+		{desc: "string from float", f: ToString(), in: 0.1, out: "0.1"},
+		{desc: "string from bool", f: ToString(), in: false, out: "false"},
+		{desc: "string from stringer", f: ToString(), in: time.Minute, out: "1m0s"},
+		{desc: "string from big float", f: ToString(), in: big.NewFloat(1.25), out: "1.25"},
+		{desc: "string from struct", f: ToString(), in: stringUser{"Sam", 30}, expectedErr: true},
+		{desc: "string from struct lossy", f: Lossy.ToString(), in: stringUser{"Sam", 30}, out: "{Sam 30}"},
+		{desc: "string from nil", f: Lossy.ToString(), in: nil, expectedErr: true},
+
+		{desc: "bool from bool", f: ToBool(), in: true, out: true},
+		{desc: "bool from string", f: ToBool(), in: "TRUE", out: true},
+		{desc: "bool from bad string", f: ToBool(), in: "yes", expectedErr: true},
+		{desc: "bool from string strict", f: Strict.ToBool(), in: "true", expectedErr: true},
+		{desc: "bool from zero", f: ToBool(), in: 0, out: false},
+		{desc: "bool from one", f: ToBool(), in: uint8(1), out: true},
+		{desc: "bool from two", f: ToBool(), in: 2, expectedErr: true},
+		{desc: "bool from two lossy", f: Lossy.ToBool(), in: 2.5, out: true},
+
+		{desc: "time from string", f: ParseTime("2006-01-02"), in: "2020-03-04", out: time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{desc: "time from time", f: ParseTime("2006-01-02"), in: time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC), out: time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{desc: "time from bad string", f: ParseTime("2006-01-02"), in: "04/03/2020", expectedErr: true},
+		{desc: "time from int", f: ParseTime("2006-01-02"), in: 1, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.f.Call(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, ErrCoerce))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestCoercedTerminals(t *testing.T) {
+	ints, err := mixed(1, "2", 3.0, int8(4)).IntsCoerced()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, ints)
+
+	int64s, err := mixed(1, "2").Int64sCoerced()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, int64s)
+
+	floats, err := Strings(ctx, []string{"1.5", "2"}).Float64sCoerced()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1.5, 2}, floats)
+
+	strs, err := Ints(ctx, []int{1, 2}).StringsCoerced()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, strs)
+
+	bools, err := Strings(ctx, []string{"true", "0"}).BoolsCoerced()
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, bools)
+
+	_, err = mixed(1, "2", "three").IntsCoerced()
+	var ee *ElementError
+	require.True(t, errors.As(err, &ee))
+	assert.Equal(t, 2, ee.Index)
+	assert.Equal(t, "three", ee.Value)
+	assert.True(t, errors.Is(err, ErrCoerce))
+
+	floats, err = mixed("1", 2.5).Coerce(Strict.ToFloat64()).Float64s()
+	assert.Error(t, err)
+}
+
+func mixed(is ...interface{}) *Collection {
+	return &Collection{ctx, is, nil}
+}
@@ -0,0 +1,321 @@
+package fu
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/numeric"
+	"github.com/samwho/fu/predicate"
+)
+
+// Equaler is implemented by types with their own notion of equality, such as
+// domain identity. Types with a typed method of the form Equal(T) bool, such
+// as time.Time, are recognised as well.
+type Equaler interface {
+	Equal(other interface{}) bool
+}
+
+var boolType = reflect.TypeOf(false)
+
+func equal(a interface{}, b interface{}) (bool, error) {
+	if e, ok := a.(Equaler); ok {
+		return e.Equal(b), nil
+	}
+	if a != nil && b != nil {
+		if m := reflect.ValueOf(a).MethodByName("Equal"); m.IsValid() {
+			t := m.Type()
+			bv := reflect.ValueOf(b)
+			if t.NumIn() == 1 && !t.IsVariadic() && t.NumOut() == 1 && t.Out(0) == boolType && bv.Type().AssignableTo(t.In(0)) {
+				return m.Call([]reflect.Value{bv})[0].Bool(), nil
+			}
+		}
+		if numeric.IsBig(a) && reflect.TypeOf(a) == reflect.TypeOf(b) {
+			c, err := numeric.Compare(a, b)
+			return c == 0, err
+		}
+	}
+	return reflect.DeepEqual(a, b), nil
+}
+
+func looseEqual(a interface{}, b interface{}) (bool, error) {
+	if numeric.IsNumeric(a) && numeric.IsNumeric(b) || numeric.IsBig(a) || numeric.IsBig(b) {
+		x, y, err := numeric.Promote(a, b)
+		if err == nil {
+			return equal(x, y)
+		}
+	}
+	return equal(a, b)
+}
+
+func equality(f func(a interface{}, b interface{}) (bool, error)) bifunction.B {
+	return bifunction.New(func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+		return f(a, b)
+	})
+}
+
+var defaultEqual = equality(equal)
+
+func Equal() bifunction.B {
+	return defaultEqual
+}
+
+// LooseEqual treats numbers of different kinds as equal when they have the
+// same value, so int(1), int64(1) and 1.0 are all equal.
+func LooseEqual() bifunction.B {
+	return equality(looseEqual)
+}
+
+func ApproxEqual(epsilon float64) bifunction.B {
+	return equality(func(a interface{}, b interface{}) (bool, error) {
+		x, err := numeric.ToFloat64(a)
+		if err != nil {
+			return false, err
+		}
+		y, err := numeric.ToFloat64(b)
+		if err != nil {
+			return false, err
+		}
+		return x == y || math.Abs(x-y) <= epsilon, nil
+	})
+}
+
+// UlpEqual considers floats equal if they are at most ulps representable
+// values apart. float32 pairs are measured in float32 steps.
+func UlpEqual(ulps uint64) bifunction.B {
+	return equality(func(a interface{}, b interface{}) (bool, error) {
+		x, err := numeric.ToFloat64(a)
+		if err != nil {
+			return false, err
+		}
+		y, err := numeric.ToFloat64(b)
+		if err != nil {
+			return false, err
+		}
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return false, nil
+		}
+		if x == y {
+			return true, nil
+		}
+		if x32, ok := a.(float32); ok {
+			if y32, ok := b.(float32); ok {
+				return ulpDistance(uint64(math.Float32bits(x32)), uint64(math.Float32bits(y32)), 32) <= ulps, nil
+			}
+		}
+		return ulpDistance(math.Float64bits(x), math.Float64bits(y), 64) <= ulps, nil
+	})
+}
+
+func ulpDistance(x uint64, y uint64, size int) uint64 {
+	// Map sign-magnitude bit patterns onto a monotonic unsigned line, with
+	// both zeros at the sign bit, so that the difference cannot overflow.
+	sign := uint64(1) << (size - 1)
+	order := func(i uint64) uint64 {
+		if i&sign != 0 {
+			return sign - (i &^ sign)
+		}
+		return sign + i
+	}
+	ox, oy := order(x), order(y)
+	if ox < oy {
+		return oy - ox
+	}
+	return ox - oy
+}
+
+func EqualBy(kf function.F, eq bifunction.B) bifunction.B {
+	return CompareBy(kf, eq)
+}
+
+func callEqual(ctx context.Context, eq bifunction.B, a interface{}, b interface{}) (bool, error) {
+	r, err := eq.Call(ctx, a, b)
+	if err != nil {
+		return false, err
+	}
+	ok, isBool := r.(bool)
+	if !isBool {
+		return false, fmt.Errorf(`equality returned non-bool: %v`, r)
+	}
+	return ok, nil
+}
+
+func EqUsing(a interface{}, eq bifunction.B) predicate.P {
+	return predicate.New(func(ctx context.Context, b interface{}) (bool, error) {
+		return callEqual(ctx, eq, a, b)
+	})
+}
+
+func LooseEq(a interface{}) predicate.P {
+	return EqUsing(a, LooseEqual())
+}
+
+func ApproxEq(a interface{}, epsilon float64) predicate.P {
+	return EqUsing(a, ApproxEqual(epsilon))
+}
+
+func UlpEq(a interface{}, ulps uint64) predicate.P {
+	return EqUsing(a, UlpEqual(ulps))
+}
+
+// EqBy compares the keys kf extracts from a and from each tested value.
+func EqBy(a interface{}, kf function.F) predicate.P {
+	return EqUsing(a, EqualBy(kf, Equal()))
+}
+
+func contains(ctx context.Context, is []interface{}, i interface{}, eq bifunction.B) (bool, error) {
+	for _, j := range is {
+		ok, err := callEqual(ctx, eq, j, i)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+func Distinct(ctx context.Context, is []interface{}, eq bifunction.B) ([]interface{}, error) {
+	ret := make([]interface{}, 0, len(is))
+	if hashable(eq, is) {
+		seen := make(map[interface{}]bool, len(is))
+		for _, i := range is {
+			if !seen[i] {
+				seen[i] = true
+				ret = append(ret, i)
+			}
+		}
+		return ret, nil
+	}
+	for _, i := range is {
+		seen, err := contains(ctx, ret, i, eq)
+		if err != nil {
+			return nil, err
+		}
+		if !seen {
+			ret = append(ret, i)
+		}
+	}
+	return ret, nil
+}
+
+func Union(ctx context.Context, a []interface{}, b []interface{}, eq bifunction.B) ([]interface{}, error) {
+	all := make([]interface{}, 0, len(a)+len(b))
+	all = append(all, a...)
+	all = append(all, b...)
+	return Distinct(ctx, all, eq)
+}
+
+func Intersect(ctx context.Context, a []interface{}, b []interface{}, eq bifunction.B) ([]interface{}, error) {
+	return setFilter(ctx, a, b, eq, true)
+}
+
+func Difference(ctx context.Context, a []interface{}, b []interface{}, eq bifunction.B) ([]interface{}, error) {
+	return setFilter(ctx, a, b, eq, false)
+}
+
+func setFilter(ctx context.Context, a []interface{}, b []interface{}, eq bifunction.B, keep bool) ([]interface{}, error) {
+	d, err := Distinct(ctx, a, eq)
+	if err != nil {
+		return nil, err
+	}
+	ret := d[:0]
+	if hashable(eq, d, b) {
+		inB := make(map[interface{}]bool, len(b))
+		for _, i := range b {
+			inB[i] = true
+		}
+		for _, i := range d {
+			if inB[i] == keep {
+				ret = append(ret, i)
+			}
+		}
+		return ret, nil
+	}
+	for _, i := range d {
+		in, err := contains(ctx, b, i, eq)
+		if err != nil {
+			return nil, err
+		}
+		if in == keep {
+			ret = append(ret, i)
+		}
+	}
+	return ret, nil
+}
+
+// hashable reports whether the set operations can use a map rather than
+// comparing every pair, which is only when eq is Equal and == agrees with
+// it for every element.
+func hashable(eq bifunction.B, iss ...[]interface{}) bool {
+	if eq != defaultEqual {
+		return false
+	}
+	for _, is := range iss {
+		for _, i := range is {
+			if i == nil {
+				continue
+			}
+			t := reflect.TypeOf(i)
+			if _, ok := t.MethodByName("Equal"); ok || !plain(t) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// plain reports whether values of t hold no pointers, interfaces or other
+// references, so that == compares them just as reflect.DeepEqual does.
+func plain(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return plain(t.Elem())
+	case reflect.Struct:
+		for n := 0; n < t.NumField(); n++ {
+			if !plain(t.Field(n).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (c *Collection) Distinct(eq bifunction.B) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = Distinct(c.ctx, c.is, eq)
+	return c
+}
+
+func (c *Collection) set(o *Collection, f func(ctx context.Context, a []interface{}, b []interface{}, eq bifunction.B) ([]interface{}, error), eq bifunction.B) *Collection {
+	if c.err != nil {
+		return c
+	}
+	if o.err != nil {
+		c.err = o.err
+		return c
+	}
+	c.is, c.err = f(c.ctx, c.is, o.is, eq)
+	return c
+}
+
+func (c *Collection) Union(o *Collection, eq bifunction.B) *Collection {
+	return c.set(o, Union, eq)
+}
+
+func (c *Collection) Intersect(o *Collection, eq bifunction.B) *Collection {
+	return c.set(o, Intersect, eq)
+}
+
+func (c *Collection) Difference(o *Collection, eq bifunction.B) *Collection {
+	return c.set(o, Difference, eq)
+}
@@ -0,0 +1,186 @@
+package fu
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type account struct {
+	ID      int
+	Balance int
+}
+
+func (a account) Equal(other interface{}) bool {
+	o, ok := other.(account)
+	return ok && o.ID == a.ID
+}
+
+func TestEqualityPredicates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	lower := function.MustLift(strings.ToLower)
+	tenth, fifth := 0.1, 0.2
+	sum := tenth + fifth
+
+	testCases := []struct {
+		desc        string
+		p           predicate.P
+		in          interface{}
+		out         bool
+		expectedErr bool
+	}{
+		{desc: "equaler", p: Eq(account{ID: 1, Balance: 5}), in: account{ID: 1, Balance: 10}, out: true},
+		{desc: "equaler differs", p: Eq(account{ID: 1}), in: account{ID: 2}, out: false},
+		{desc: "neq equaler", p: Neq(account{ID: 1, Balance: 5}), in: account{ID: 1}, out: false},
+		{desc: "typed equal method", p: Eq(now), in: now.In(time.FixedZone("x", 3600)), out: true},
+		{desc: "deep equal", p: Eq([]int{1}), in: []int{1}, out: true},
+		{desc: "strict kinds", p: Eq(1), in: int64(1), out: false},
+		{desc: "nil", p: Eq(nil), in: nil, out: true},
+		{desc: "big", p: Eq(big.NewRat(1, 2)), in: big.NewRat(2, 4), out: true},
+
+		{desc: "loose int kinds", p: LooseEq(1), in: int64(1), out: true},
+		{desc: "loose int float", p: LooseEq(1), in: 1.0, out: true},
+		{desc: "loose unequal", p: LooseEq(1), in: 1.5, out: false},
+		{desc: "loose big", p: LooseEq(big.NewInt(2)), in: uint8(2), out: true},
+		{desc: "loose signed unsigned", p: LooseEq(int64(-1)), in: uint64(math.MaxUint64), out: false},
+		{desc: "loose large unsigned", p: LooseEq(uint64(math.MaxUint64)), in: int8(-1), out: false},
+		{desc: "loose large unsigned min int", p: LooseEq(uint64(1 << 63)), in: int64(math.MinInt64), out: false},
+		{desc: "loose unsigned signed", p: LooseEq(uint(7)), in: int64(7), out: true},
+		{desc: "loose strings", p: LooseEq("a"), in: "a", out: true},
+		{desc: "loose mixed", p: LooseEq("1"), in: 1, out: false},
+
+		{desc: "approx", p: ApproxEq(0.3, 1e-9), in: sum, out: true},
+		{desc: "approx far", p: ApproxEq(0.3, 1e-9), in: 0.31, out: false},
+		{desc: "approx mixed kinds", p: ApproxEq(1, 0.01), in: float32(1.001), out: true},
+		{desc: "approx inf", p: ApproxEq(math.Inf(1), 1), in: math.Inf(1), out: true},
+		{desc: "approx non-numeric", p: ApproxEq(1, 0.1), in: "1", expectedErr: true},
+
+		{desc: "ulp", p: UlpEq(0.3, 1), in: sum, out: true},
+		{desc: "ulp zero", p: UlpEq(0.3, 0), in: sum, out: false},
+		{desc: "exact", p: Eq(0.3), in: sum, out: false},
+		{desc: "ulp signed zero", p: UlpEq(0.0, 0), in: math.Copysign(0, -1), out: true},
+		{desc: "ulp across zero", p: UlpEq(math.SmallestNonzeroFloat64, 2), in: -math.SmallestNonzeroFloat64, out: true},
+		{desc: "ulp nan", p: UlpEq(math.NaN(), 10), in: math.NaN(), out: false},
+		{desc: "ulp nan one", p: UlpEq(math.NaN(), 1), in: math.NaN(), out: false},
+		{desc: "ulp float32 nan", p: UlpEq(float32(math.NaN()), 1), in: float32(math.NaN()), out: false},
+		{desc: "ulp max apart", p: UlpEq(-math.MaxFloat64, 1<<62), in: math.MaxFloat64, out: false},
+		{desc: "ulp max within", p: UlpEq(-math.MaxFloat64, math.MaxUint64), in: math.MaxFloat64, out: true},
+		{desc: "ulp float32 max apart", p: UlpEq(float32(-math.MaxFloat32), 1<<31), in: float32(math.MaxFloat32), out: false},
+		{desc: "ulp float32 max within", p: UlpEq(float32(-math.MaxFloat32), 1<<32), in: float32(math.MaxFloat32), out: true},
+		{desc: "ulp float32", p: UlpEq(float32(1), 1), in: math.Nextafter32(1, 2), out: true},
+		{desc: "ulp float32 far", p: UlpEq(float32(1), 1), in: math.Nextafter32(math.Nextafter32(1, 2), 2), out: false},
+
+		{desc: "eq by", p: EqBy("HELLO", lower), in: "hello", out: true},
+		{desc: "eq by field", p: EqBy(account{ID: 1, Balance: 2}, Field("Balance")), in: account{ID: 3, Balance: 2}, out: true},
+		{desc: "eq by error", p: EqBy("a", lower), in: 1, expectedErr: true},
+		{desc: "eq using bad", p: EqUsing(1, Sum()), in: 1, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.p.Test(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	d, err := Distinct(ctx, []interface{}{1, 2, 1, int64(1), 3}, Equal())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, int64(1), 3}, d)
+
+	d, err = Distinct(ctx, []interface{}{1, 2, 1, int64(1), 1.0}, LooseEqual())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2}, d)
+
+	d, err = Distinct(ctx, []interface{}{account{1, 1}, account{1, 2}, account{2, 1}}, Equal())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{account{1, 1}, account{2, 1}}, d)
+
+	x, y := &[2]int{1, 2}, &[2]int{1, 2}
+	d, err = Distinct(ctx, []interface{}{[2]int{1, 2}, "a", [2]int{1, 2}, nil, "a", nil}, Equal())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[2]int{1, 2}, "a", nil}, d)
+
+	d, err = Distinct(ctx, []interface{}{1, []int{1}, 1, []int{1}}, Equal())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, []int{1}}, d)
+
+	d, err = Distinct(ctx, []interface{}{x, y}, Equal())
+	require.NoError(t, err)
+	assert.Len(t, d, 1)
+
+	d, err = Distinct(ctx, []interface{}{"a", "A", "b"}, EqualBy(function.MustLift(strings.ToLower), Equal()))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, d)
+}
+
+func TestSetOperations(t *testing.T) {
+	a := []interface{}{1, 2, 3, 3}
+	b := []interface{}{int64(3), 4, 2}
+
+	u, err := Union(ctx, a, b, LooseEqual())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, u)
+
+	i, err := Intersect(ctx, a, b, LooseEqual())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 3}, i)
+
+	i, err = Intersect(ctx, a, b, Equal())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2}, i)
+
+	d, err := Difference(ctx, a, b, LooseEqual())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1}, d)
+
+	d, err = Difference(ctx, a, b, Equal())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 3}, d)
+
+	d, err = Difference(ctx, []interface{}{[]int{1}, []int{2}}, []interface{}{[]int{2}}, Equal())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]int{1}}, d)
+
+	i, err = Intersect(ctx, []interface{}{"x", "y", "x"}, []interface{}{"x", "z"}, Equal())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"x"}, i)
+}
+
+func TestCollectionSetOperations(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 1, 2}).Distinct(Equal()).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, result)
+
+	result, err = Ints(ctx, []int{1, 2}).Union(Ints(ctx, []int{2, 3}), Equal()).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, result)
+
+	result, err = Ints(ctx, []int{1, 2}).Intersect(Ints(ctx, []int{2, 3}), Equal()).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, result)
+
+	result, err = Ints(ctx, []int{1, 2}).Difference(Ints(ctx, []int{2, 3}), Equal()).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, result)
+
+	_, err = Ints(ctx, []int{1}).Union(Strings(ctx, []string{"a"}).Map(Add(1)), Equal()).Ints()
+	assert.Error(t, err)
+}
@@ -0,0 +1,347 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/expr"
+	"github.com/samwho/fu/fieldpath"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/internal/lift"
+	"github.com/samwho/fu/numeric"
+	"github.com/samwho/fu/predicate"
+)
+
+// Expression is a compiled expression such as `Age >= 18 && Name =~ /^A/`.
+// It is a predicate.P when it evaluates to a bool, and a function.F for any
+// result, such as `Price * Qty`.
+//
+// Identifiers are field paths resolved against each element, and `it` is the
+// element itself. Comparisons and arithmetic behave as Gt, Eq, Sum, Multiply
+// and friends do, except that literals are converted to the type of the other
+// operand where that loses nothing, as untyped constants are in Go, and that
+// arithmetic on mixed numeric types is Promoted.
+type Expression struct {
+	src string
+	f   function.F
+}
+
+func Expr(s string) (*Expression, error) {
+	n, err := expr.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	c := &exprCompiler{src: s}
+	e, err := c.compile(n)
+	if err != nil {
+		return nil, err
+	}
+	return &Expression{src: s, f: e.f}, nil
+}
+
+func MustExpr(s string) *Expression {
+	e, err := Expr(s)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (e *Expression) String() string {
+	return e.src
+}
+
+func (e *Expression) Call(ctx context.Context, i interface{}) (interface{}, error) {
+	return e.f.Call(ctx, i)
+}
+
+func (e *Expression) Test(ctx context.Context, i interface{}) (bool, error) {
+	r, err := e.f.Call(ctx, i)
+	if err != nil {
+		return false, err
+	}
+	b, ok := r.(bool)
+	if !ok {
+		return false, &expr.Error{Expr: e.src, Pos: 0, Err: fmt.Errorf(`expected bool result, got %T`, r)}
+	}
+	return b, nil
+}
+
+type exprCompiler struct {
+	src string
+}
+
+// compiled is a node's function along with whether it is made only of
+// literals, in which case its result adapts to the type of the other operand.
+type compiled struct {
+	f       function.F
+	literal bool
+}
+
+func (c *exprCompiler) errorf(n expr.Node, err error) error {
+	var ee *expr.Error
+	if errors.As(err, &ee) {
+		return err
+	}
+	return &expr.Error{Expr: c.src, Pos: n.Pos(), Err: err}
+}
+
+// fn positions any error f returns at n, unless a deeper node already has.
+func (c *exprCompiler) fn(n expr.Node, f function.Fn) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		r, err := f(ctx, i)
+		if err != nil {
+			return nil, c.errorf(n, err)
+		}
+		return r, nil
+	})
+}
+
+func (c *exprCompiler) compile(n expr.Node) (compiled, error) {
+	switch n := n.(type) {
+	case *expr.Literal:
+		v := n.Value
+		return compiled{function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+			return v, nil
+		}), true}, nil
+	case *expr.Path:
+		if n.Path == "" {
+			return compiled{Identity(), false}, nil
+		}
+		p, err := fieldpath.Parse(n.Path)
+		if err != nil {
+			return compiled{}, c.errorf(n, err)
+		}
+		return compiled{c.fn(n, pathFn(p).Call), false}, nil
+	case *expr.Regex:
+		return compiled{}, c.errorf(n, errors.New("regular expression outside =~ or !~"))
+	case *expr.List:
+		return c.list(n)
+	case *expr.Call:
+		if aggregates[strings.ToUpper(n.Name)] {
+			return compiled{}, c.errorf(n, fmt.Errorf(`%s can only be used as a whole column in a query`, n.Name))
+		}
+		return compiled{}, c.errorf(n, fmt.Errorf(`unknown function %q`, n.Name))
+	case *expr.Unary:
+		x, err := c.compile(n.X)
+		if err != nil {
+			return compiled{}, err
+		}
+		if n.Op == "!" {
+			return compiled{c.fn(n, predicateFn(Not(c.predicate(n.X, x.f)))), x.literal}, nil
+		}
+		return compiled{c.fn(n, function.Compose(x.f, Neg()).Call), x.literal}, nil
+	case *expr.Binary:
+		return c.binary(n)
+	}
+	return compiled{}, c.errorf(n, fmt.Errorf(`unknown node %T`, n))
+}
+
+func (c *exprCompiler) list(n *expr.List) (compiled, error) {
+	fs := make([]function.F, 0, len(n.Elems))
+	literal := true
+	for _, e := range n.Elems {
+		x, err := c.compile(e)
+		if err != nil {
+			return compiled{}, err
+		}
+		fs = append(fs, x.f)
+		literal = literal && x.literal
+	}
+	return compiled{c.fn(n, func(ctx context.Context, i interface{}) (interface{}, error) {
+		is := make([]interface{}, 0, len(fs))
+		for _, f := range fs {
+			r, err := f.Call(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+			is = append(is, r)
+		}
+		return is, nil
+	}), literal}, nil
+}
+
+func predicateFn(p predicate.P) function.Fn {
+	return func(ctx context.Context, i interface{}) (interface{}, error) {
+		return p.Test(ctx, i)
+	}
+}
+
+// predicate requires f, compiled from n, to return a bool.
+func (c *exprCompiler) predicate(n expr.Node, f function.F) predicate.P {
+	return predicate.New(func(ctx context.Context, i interface{}) (bool, error) {
+		r, err := f.Call(ctx, i)
+		if err != nil {
+			return false, err
+		}
+		b, ok := r.(bool)
+		if !ok {
+			return false, c.errorf(n, fmt.Errorf(`expected bool, got %T`, r))
+		}
+		return b, nil
+	})
+}
+
+var exprArithmetic = map[string]func() bifunction.B{
+	"+": Sum,
+	"-": NegativeSum,
+	"*": Multiply,
+	"/": Quotient,
+	"%": Remainder,
+}
+
+var exprComparisons = map[string]func(interface{}) predicate.P{
+	"==": Eq,
+	"!=": Neq,
+	"<":  Lt,
+	"<=": Lte,
+	">":  Gt,
+	">=": Gte,
+}
+
+func (c *exprCompiler) binary(n *expr.Binary) (compiled, error) {
+	x, err := c.compile(n.X)
+	if err != nil {
+		return compiled{}, err
+	}
+	if n.Op == "=~" || n.Op == "!~" {
+		return c.match(n, x)
+	}
+	y, err := c.compile(n.Y)
+	if err != nil {
+		return compiled{}, err
+	}
+	literal := x.literal && y.literal
+
+	switch n.Op {
+	case "&&":
+		return compiled{c.fn(n, predicateFn(And(c.predicate(n.X, x.f), c.predicate(n.Y, y.f)))), literal}, nil
+	case "||":
+		return compiled{c.fn(n, predicateFn(Or(c.predicate(n.X, x.f), c.predicate(n.Y, y.f)))), literal}, nil
+	}
+
+	var op func(ctx context.Context, a interface{}, b interface{}) (interface{}, error)
+	if bf, ok := exprArithmetic[n.Op]; ok {
+		op = Promoted(bf()).Call
+	} else if p, ok := exprComparisons[n.Op]; ok {
+		op = func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+			return p(b).Test(ctx, a)
+		}
+	} else if n.Op == "contains" {
+		op = exprContains
+	} else if n.Op == "in" {
+		op = func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+			return exprContains(ctx, b, a)
+		}
+	} else {
+		return compiled{}, c.errorf(n, fmt.Errorf(`unknown operator %q`, n.Op))
+	}
+
+	return compiled{c.fn(n, func(ctx context.Context, i interface{}) (interface{}, error) {
+		a, err := x.f.Call(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		b, err := y.f.Call(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		a, b = adaptLiterals(a, b, x.literal, y.literal)
+		return op(ctx, a, b)
+	}), literal}, nil
+}
+
+// match compiles =~ and !~, whose pattern must be a regular expression or
+// string literal so that it can be checked up front.
+func (c *exprCompiler) match(n *expr.Binary, x compiled) (compiled, error) {
+	var pattern string
+	switch y := n.Y.(type) {
+	case *expr.Regex:
+		pattern = y.Pattern
+	case *expr.Literal:
+		s, ok := y.Value.(string)
+		if !ok {
+			return compiled{}, c.errorf(y, errors.New("expected regular expression"))
+		}
+		pattern = s
+	default:
+		return compiled{}, c.errorf(y, errors.New("expected regular expression"))
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return compiled{}, c.errorf(n.Y, err)
+	}
+	p := Matches(pattern)
+	if n.Op == "!~" {
+		p = Not(p)
+	}
+	return compiled{c.fn(n, function.Compose(x.f, function.New(predicateFn(p))).Call), x.literal}, nil
+}
+
+// adaptLiterals converts a literal to the type of a non-literal operand, so
+// that `Age > 18` works when Age is an int64 and `Status == "on"` works when
+// Status is a named string type. nil becomes a typed nil, so `Address != nil`
+// works for pointers. Two numeric literals are promoted.
+func adaptLiterals(a interface{}, b interface{}, aLiteral bool, bLiteral bool) (interface{}, interface{}) {
+	switch {
+	case aLiteral && !bLiteral:
+		a = convertLiteral(a, b)
+	case bLiteral && !aLiteral:
+		b = convertLiteral(b, a)
+	case aLiteral && bLiteral && numeric.IsNumeric(a) && numeric.IsNumeric(b):
+		if x, y, err := numeric.Promote(a, b); err == nil {
+			a, b = x, y
+		}
+	}
+	return a, b
+}
+
+func convertLiteral(lit interface{}, typed interface{}) interface{} {
+	if typed == nil {
+		return lit
+	}
+	if v, err := lift.Convert(lit, reflect.TypeOf(typed)); err == nil {
+		return v.Interface()
+	}
+	return lit
+}
+
+// exprContains reports whether haystack, a string, slice, array or map, contains
+// needle as a substring, element or key respectively.
+func exprContains(ctx context.Context, haystack interface{}, needle interface{}) (interface{}, error) {
+	if haystack == nil {
+		return nil, errors.New("cannot search nil")
+	}
+	v := reflect.ValueOf(haystack)
+	switch v.Kind() {
+	case reflect.String:
+		s, err := asString("contains", needle)
+		if err != nil {
+			return nil, err
+		}
+		return Contains(s).Test(ctx, haystack)
+	case reflect.Slice, reflect.Array:
+		for n := 0; n < v.Len(); n++ {
+			e := v.Index(n).Interface()
+			ok, err := equal(e, convertLiteral(needle, e))
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	case reflect.Map:
+		k, err := lift.Convert(needle, v.Type().Key())
+		if err != nil {
+			return false, nil
+		}
+		return v.MapIndex(k).IsValid(), nil
+	}
+	return nil, fmt.Errorf(`cannot search %T`, haystack)
+}
@@ -0,0 +1,165 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	eof tokenKind = iota
+	ident
+	integer
+	float
+	str
+	regex
+	punct
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	text string
+	// value holds the decoded value of literals.
+	value interface{}
+}
+
+var puncts = []string{
+	"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<>",
+	"!", "<", ">", "=", "+", "-", "*", "/", "%", "(", ")", "[", "]", ".", ",",
+}
+
+type lexer struct {
+	src  string
+	pos  int
+	prev token
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.src) && strings.IndexByte(" \t\r\n", l.src[l.pos]) >= 0 {
+		l.pos++
+	}
+	t, err := l.scan()
+	l.prev = t
+	return t, err
+}
+
+func (l *lexer) scan() (token, error) {
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: eof, pos: start}, nil
+	}
+	c := l.src[l.pos]
+	switch {
+	case isIdentStart(c):
+		for l.pos < len(l.src) && (isIdentStart(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: ident, pos: start, text: l.src[start:l.pos]}, nil
+	case isDigit(c):
+		return l.number()
+	case c == '"' || c == '\'':
+		return l.quoted(c)
+	case c == '/' && l.prev.kind == punct && (l.prev.text == "=~" || l.prev.text == "!~"):
+		return l.regex()
+	}
+	for _, p := range puncts {
+		if strings.HasPrefix(l.src[l.pos:], p) {
+			l.pos += len(p)
+			return token{kind: punct, pos: start, text: p}, nil
+		}
+	}
+	return token{}, errorf(l.src, start, "unexpected %q", c)
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	kind := integer
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+	if l.pos+1 < len(l.src) && l.src[l.pos] == '.' && isDigit(l.src[l.pos+1]) {
+		kind = float
+		l.pos++
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = float
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.pos++
+		}
+	}
+	text := l.src[start:l.pos]
+	if kind == integer {
+		n, err := strconv.ParseInt(text, 10, 0)
+		if err != nil {
+			return token{}, errorf(l.src, start, "invalid number %q", text)
+		}
+		return token{kind: integer, pos: start, text: text, value: int(n)}, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return token{}, errorf(l.src, start, "invalid number %q", text)
+	}
+	return token{kind: float, pos: start, text: text, value: f}, nil
+}
+
+// quoted reads a double-quoted string with Go escapes, or a single-quoted
+// string without any.
+func (l *lexer) quoted(q byte) (token, error) {
+	start := l.pos
+	for l.pos++; l.pos < len(l.src); l.pos++ {
+		switch l.src[l.pos] {
+		case '\\':
+			if q == '"' {
+				l.pos++
+			}
+		case q:
+			l.pos++
+			text := l.src[start:l.pos]
+			if q == '\'' {
+				return token{kind: str, pos: start, text: text, value: text[1 : len(text)-1]}, nil
+			}
+			s, err := strconv.Unquote(text)
+			if err != nil {
+				return token{}, errorf(l.src, start, "invalid string %s", text)
+			}
+			return token{kind: str, pos: start, text: text, value: s}, nil
+		}
+	}
+	return token{}, errorf(l.src, start, "unterminated string")
+}
+
+// regex reads /pattern/, in which only \/ is unescaped.
+func (l *lexer) regex() (token, error) {
+	start := l.pos
+	var b strings.Builder
+	for l.pos++; l.pos < len(l.src); l.pos++ {
+		switch c := l.src[l.pos]; {
+		case c == '\\' && l.pos+1 < len(l.src) && l.src[l.pos+1] == '/':
+			b.WriteByte('/')
+			l.pos++
+		case c == '/':
+			l.pos++
+			return token{kind: regex, pos: start, text: l.src[start:l.pos], value: b.String()}, nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return token{}, errorf(l.src, start, "unterminated regular expression")
+}
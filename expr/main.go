@@ -0,0 +1,428 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Error struct {
+	Expr string
+	Pos  int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf(`expr %q: %v at position %d`, e.Expr, e.Err, e.Pos)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func errorf(src string, pos int, format string, args ...interface{}) error {
+	return &Error{Expr: src, Pos: pos, Err: fmt.Errorf(format, args...)}
+}
+
+type Node interface {
+	Pos() int
+}
+
+// Literal is a number, string, bool or nil written in the expression. Whole
+// numbers are ints and the rest are float64s.
+type Literal struct {
+	At    int
+	Value interface{}
+}
+
+type Regex struct {
+	At      int
+	Pattern string
+}
+
+// Path is a field path such as `Address.City` or `Tags[0]`, in the syntax
+// accepted by fieldpath.Parse. An empty Path, written `it`, is the element
+// itself.
+type Path struct {
+	At   int
+	Path string
+}
+
+// Call is a function call such as `COUNT(*)`, in which case Star is set.
+type Call struct {
+	At   int
+	Name string
+	Args []Node
+	Star bool
+}
+
+type List struct {
+	At    int
+	Elems []Node
+}
+
+// Unary operators are "!" and "-". Operators are normalised, so `NOT x`
+// parses to "!".
+type Unary struct {
+	At int
+	Op string
+	X  Node
+}
+
+// Binary operators are "||", "&&", the comparisons "==", "!=", "<", "<=",
+// ">", ">=", "=~", "!~", "contains" and "in", and the arithmetic operators
+// "+", "-", "*", "/" and "%".
+type Binary struct {
+	At int
+	Op string
+	X  Node
+	Y  Node
+}
+
+func (n *Literal) Pos() int { return n.At }
+func (n *Regex) Pos() int   { return n.At }
+func (n *Path) Pos() int    { return n.At }
+func (n *Call) Pos() int    { return n.At }
+func (n *List) Pos() int    { return n.At }
+func (n *Unary) Pos() int   { return n.At }
+func (n *Binary) Pos() int  { return n.At }
+
+type parser struct {
+	src string
+	lex *lexer
+	tok token
+	// sql enables the SQL spellings used by ParseQuery.
+	sql bool
+}
+
+// Parse parses s into a tree of Nodes. Operators bind, from loosest to
+// tightest: "||", "&&", "!", comparisons, "+" and "-", then "*", "/" and "%".
+func Parse(s string) (Node, error) {
+	p := &parser{src: s, lex: &lexer{src: s}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == eof {
+		return nil, errorf(s, 0, "empty expression")
+	}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != eof {
+		return nil, p.unexpected()
+	}
+	return n, nil
+}
+
+func (p *parser) advance() error {
+	t, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = t
+	return nil
+}
+
+func (p *parser) is(text string) bool {
+	switch p.tok.kind {
+	case punct:
+		return p.tok.text == text
+	case ident:
+		if p.sql {
+			return strings.EqualFold(p.tok.text, text)
+		}
+		return p.tok.text == text
+	}
+	return false
+}
+
+// aliases are the SQL spellings of operators, accepted only by ParseQuery.
+var aliases = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+	"=":   "==",
+	"<>":  "!=",
+}
+
+// op returns the normalised operator if the current token is one of ops.
+func (p *parser) op(ops ...string) string {
+	for _, o := range ops {
+		a, alias := aliases[o]
+		if alias && !p.sql || !p.is(o) {
+			continue
+		}
+		if alias {
+			return a
+		}
+		return o
+	}
+	return ""
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == eof {
+		return errorf(p.src, p.tok.pos, "unexpected end of expression")
+	}
+	return errorf(p.src, p.tok.pos, "unexpected %q", p.tok.text)
+}
+
+func (p *parser) expect(text string) error {
+	if !p.is(text) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) binary(ops []string, operand func() (Node, error)) (Node, error) {
+	x, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.op(ops...)
+		if op == "" {
+			return x, nil
+		}
+		at := p.tok.pos
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		y, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		x = &Binary{At: at, Op: op, X: x, Y: y}
+	}
+}
+
+func (p *parser) or() (Node, error) {
+	return p.binary([]string{"||", "or"}, p.and)
+}
+
+func (p *parser) and() (Node, error) {
+	return p.binary([]string{"&&", "and"}, p.not)
+}
+
+func (p *parser) not() (Node, error) {
+	if p.op("!", "not") == "" {
+		return p.comparison()
+	}
+	at := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	x, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+	return &Unary{At: at, Op: "!", X: x}, nil
+}
+
+var comparisons = []string{"==", "!=", "<", "<=", ">", ">=", "=~", "!~", "contains", "in", "=", "<>"}
+
+// comparison does not associate, so `a < b < c` is an error.
+func (p *parser) comparison() (Node, error) {
+	x, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	if op := p.op(comparisons...); op != "" {
+		at := p.tok.pos
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var y Node
+		if (op == "=~" || op == "!~") && p.tok.kind == regex {
+			y = &Regex{At: p.tok.pos, Pattern: p.tok.value.(string)}
+			err = p.advance()
+		} else {
+			y, err = p.sum()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p.op(comparisons...) != "" {
+			return nil, p.unexpected()
+		}
+		return &Binary{At: at, Op: op, X: x, Y: y}, nil
+	}
+	return x, nil
+}
+
+func (p *parser) sum() (Node, error) {
+	return p.binary([]string{"+", "-"}, p.product)
+}
+
+func (p *parser) product() (Node, error) {
+	return p.binary([]string{"*", "/", "%"}, p.negation)
+}
+
+func (p *parser) negation() (Node, error) {
+	if !p.is("-") {
+		return p.postfix()
+	}
+	at := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	x, err := p.negation()
+	if err != nil {
+		return nil, err
+	}
+	switch l := x.(type) {
+	case *Literal:
+		switch v := l.Value.(type) {
+		case int:
+			return &Literal{At: at, Value: -v}, nil
+		case float64:
+			return &Literal{At: at, Value: -v}, nil
+		}
+	}
+	return &Unary{At: at, Op: "-", X: x}, nil
+}
+
+// postfix reads an identifier and any field accesses and literal indexes
+// that follow it, such as `Orders[0].Items["sku"]`.
+func (p *parser) postfix() (Node, error) {
+	if p.tok.kind != ident || p.keyword(p.tok.text) && !p.is("it") {
+		return p.primary()
+	}
+	at := p.tok.pos
+	path := p.tok.text
+	if p.is("it") {
+		path = ""
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.is("(") && path != "" {
+		return p.call(at, path)
+	}
+	for {
+		switch {
+		case p.is("."):
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != ident {
+				return nil, p.unexpected()
+			}
+			if path != "" {
+				path += "."
+			}
+			path += p.tok.text
+		case p.is("["):
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			switch p.tok.kind {
+			case integer:
+				path += "[" + p.tok.text + "]"
+			case str:
+				path += "[" + strconv.Quote(p.tok.value.(string)) + "]"
+			default:
+				return nil, errorf(p.src, p.tok.pos, "index must be an integer or string literal")
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if !p.is("]") {
+				return nil, p.unexpected()
+			}
+		default:
+			return &Path{At: at, Path: path}, nil
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) keyword(s string) bool {
+	switch s {
+	case "true", "false", "nil", "it", "contains", "in":
+		return true
+	}
+	if p.sql {
+		switch strings.ToLower(s) {
+		case "true", "false", "nil", "null", "it", "contains", "in", "and", "or", "not":
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) call(at int, name string) (Node, error) {
+	c := &Call{At: at, Name: name}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.is("*") {
+		c.Star = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return c, p.expect(")")
+	}
+	for !p.is(")") {
+		if len(c.Args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		c.Args = append(c.Args, n)
+	}
+	return c, p.advance()
+}
+
+func (p *parser) primary() (Node, error) {
+	t := p.tok
+	switch {
+	case t.kind == integer || t.kind == float || t.kind == str:
+		return &Literal{At: t.pos, Value: t.value}, p.advance()
+	case p.is("true"):
+		return &Literal{At: t.pos, Value: true}, p.advance()
+	case p.is("false"):
+		return &Literal{At: t.pos, Value: false}, p.advance()
+	case p.is("nil") || p.sql && p.is("null"):
+		return &Literal{At: t.pos}, p.advance()
+	case p.is("("):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return n, p.expect(")")
+	case p.is("["):
+		return p.list()
+	}
+	return nil, p.unexpected()
+}
+
+func (p *parser) list() (Node, error) {
+	l := &List{At: p.tok.pos}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	for !p.is("]") {
+		if len(l.Elems) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		l.Elems = append(l.Elems, n)
+	}
+	return l, p.advance()
+}
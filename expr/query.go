@@ -0,0 +1,214 @@
+package expr
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Query is a parsed statement of the form
+//
+//	SELECT cols FROM name [WHERE cond] [GROUP BY exprs]
+//	    [ORDER BY expr [ASC|DESC], ...] [LIMIT n] [OFFSET n]
+//
+// in which every expression uses the syntax accepted by Parse, and in which
+// keywords are case-insensitive and `and`, `or`, `not`, `=` and `<>` may be
+// written for "&&", "||", "!", "==" and "!=".
+type Query struct {
+	Columns []Column
+	From    string
+	Where   Node
+	GroupBy []Column
+	OrderBy []Order
+	// Limit is -1 if there is no LIMIT clause.
+	Limit  int
+	Offset int
+}
+
+// Column is a selected or grouped expression, or every field if Expr is nil.
+// Text is the expression as written and Name is the alias given with AS, or
+// else Text.
+type Column struct {
+	Expr Node
+	Text string
+	Name string
+}
+
+type Order struct {
+	Expr Node
+	Name string
+	Desc bool
+}
+
+// SameName reports whether two expressions as written are the same, ignoring
+// case and whitespace, so that `count(*)` matches `COUNT( * )`.
+func SameName(a string, b string) bool {
+	strip := func(s string) string {
+		return strings.Join(strings.Fields(s), "")
+	}
+	return strings.EqualFold(strip(a), strip(b))
+}
+
+func ParseQuery(s string) (*Query, error) {
+	p := &parser{src: s, lex: &lexer{src: s}, sql: true}
+	q := &Query{Limit: -1}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("select"); err != nil {
+		return nil, err
+	}
+	for {
+		c, err := p.column()
+		if err != nil {
+			return nil, err
+		}
+		q.Columns = append(q.Columns, c)
+		if !p.is(",") {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.expect("from"); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != ident {
+		return nil, p.unexpected()
+	}
+	q.From = p.tok.text
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.is("where") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		q.Where = n
+	}
+
+	if p.is("group") {
+		if err := p.by(); err != nil {
+			return nil, err
+		}
+		for {
+			start := p.tok.pos
+			n, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			q.GroupBy = append(q.GroupBy, newColumn(n, strings.TrimSpace(s[start:p.tok.pos])))
+			if !p.is(",") {
+				break
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if p.is("order") {
+		if err := p.by(); err != nil {
+			return nil, err
+		}
+		for {
+			start := p.tok.pos
+			n, err := p.or()
+			if err != nil {
+				return nil, err
+			}
+			o := Order{Expr: n, Name: strings.TrimSpace(s[start:p.tok.pos])}
+			if p.is("desc") || p.is("asc") {
+				o.Desc = p.is("desc")
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+			}
+			q.OrderBy = append(q.OrderBy, o)
+			if !p.is(",") {
+				break
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if p.is("limit") {
+		n, err := p.count()
+		if err != nil {
+			return nil, err
+		}
+		q.Limit = n
+	}
+	if p.is("offset") {
+		n, err := p.count()
+		if err != nil {
+			return nil, err
+		}
+		q.Offset = n
+	}
+
+	if p.tok.kind != eof {
+		return nil, p.unexpected()
+	}
+	return q, nil
+}
+
+func (p *parser) column() (Column, error) {
+	if p.is("*") {
+		return Column{Text: "*", Name: "*"}, p.advance()
+	}
+	start := p.tok.pos
+	n, err := p.or()
+	if err != nil {
+		return Column{}, err
+	}
+	c := newColumn(n, strings.TrimSpace(p.src[start:p.tok.pos]))
+	if p.is("as") {
+		if err := p.advance(); err != nil {
+			return Column{}, err
+		}
+		switch p.tok.kind {
+		case ident:
+			c.Name = p.tok.text
+		case str:
+			c.Name = p.tok.value.(string)
+		default:
+			return Column{}, p.unexpected()
+		}
+		return c, p.advance()
+	}
+	return c, nil
+}
+
+func newColumn(n Node, text string) Column {
+	return Column{Expr: n, Text: text, Name: text}
+}
+
+func (p *parser) by() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+	return p.expect("by")
+}
+
+func (p *parser) count() (int, error) {
+	if err := p.advance(); err != nil {
+		return 0, err
+	}
+	if p.tok.kind != integer {
+		return 0, p.unexpected()
+	}
+	n, err := strconv.Atoi(p.tok.text)
+	if err != nil {
+		return 0, p.unexpected()
+	}
+	return n, p.advance()
+}
@@ -0,0 +1,199 @@
+package fu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samwho/fu/expr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type exprStatus string
+
+type exprCustomer struct {
+	Name    string
+	Age     int64
+	Status  exprStatus
+	Tags    []string
+	Price   float64
+	Qty     int
+	Meta    map[string]int
+	Address *pathAddress
+}
+
+var alice = exprCustomer{
+	Name:    "Alice",
+	Age:     30,
+	Status:  "active",
+	Tags:    []string{"vip", "beta"},
+	Price:   2.5,
+	Qty:     4,
+	Meta:    map[string]int{"visits": 3},
+	Address: &pathAddress{City: "Leeds"},
+}
+
+func TestExprPredicates(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc        string
+		expr        string
+		in          interface{}
+		out         bool
+		expectedErr bool
+	}{
+		{desc: "request example", expr: `Age >= 18 && Name =~ /^A/ && Tags contains "vip"`, in: alice, out: true},
+		{desc: "comparison", expr: `Age < 18`, in: alice, out: false},
+		{desc: "equality", expr: `Name == "Alice"`, in: alice, out: true},
+		{desc: "named type literal", expr: `Status == "active"`, in: alice, out: true},
+		{desc: "inequality", expr: `Name != 'Bob'`, in: alice, out: true},
+		{desc: "or", expr: `Age > 100 || Name == "Alice"`, in: alice, out: true},
+		{desc: "not", expr: `!(Age > 100)`, in: alice, out: true},
+		{desc: "precedence", expr: `Age > 100 || Age > 18 && Qty == 4`, in: alice, out: true},
+		{desc: "nested path", expr: `Address.City == "Leeds"`, in: alice, out: true},
+		{desc: "index", expr: `Tags[1] == "beta"`, in: alice, out: true},
+		{desc: "map key", expr: `Meta["visits"] > 2`, in: alice, out: true},
+		{desc: "map contains", expr: `Meta contains "visits"`, in: alice, out: true},
+		{desc: "in list", expr: `Name in ["Bob", "Alice"]`, in: alice, out: true},
+		{desc: "not in list", expr: `!(Qty in [1, 2, 3])`, in: alice, out: true},
+		{desc: "string contains", expr: `Name contains "lic"`, in: alice, out: true},
+		{desc: "regex mismatch", expr: `Name !~ /^B/`, in: alice, out: true},
+		{desc: "regex string", expr: `Name =~ "e$"`, in: alice, out: true},
+		{desc: "regex slash", expr: `it =~ /a\/b/`, in: "a/b", out: true},
+		{desc: "arithmetic comparison", expr: `Price * 2 > 4.5`, in: alice, out: true},
+		{desc: "it", expr: `it % 2 == 0`, in: 4, out: true},
+		{desc: "negative", expr: `it > -1`, in: 0, out: true},
+		{desc: "bool literal", expr: `true && it`, in: true, out: true},
+		{desc: "short circuit", expr: `Age < 18 && Missing > 1`, in: alice, out: false},
+		{desc: "nil", expr: `Address != nil`, in: exprCustomer{}, out: false},
+		{desc: "not nil", expr: `Address != nil`, in: alice, out: true},
+		{desc: "mismatched types", expr: `Price > Qty`, in: alice, expectedErr: true},
+		{desc: "missing field", expr: `Missing > 1`, in: alice, expectedErr: true},
+		{desc: "not bool", expr: `Age`, in: alice, expectedErr: true},
+		{desc: "and not bool", expr: `Age && true`, in: alice, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			e, err := Expr(tC.expr)
+			require.NoError(t, err)
+			res, err := e.Test(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestExprFunctions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		expr string
+		in   interface{}
+		out  interface{}
+	}{
+		{desc: "product", expr: `Price * 4`, in: alice, out: 10.0},
+		{desc: "mixed product", expr: `Price * Qty`, in: alice, out: 10.0},
+		{desc: "mixed integers", expr: `Age + Qty`, in: alice, out: int64(34)},
+		{desc: "projection", expr: `Address.City`, in: alice, out: "Leeds"},
+		{desc: "precedence", expr: `1 + 2 * 3`, in: nil, out: 7},
+		{desc: "parens", expr: `(1 + 2) * 3`, in: nil, out: 9},
+		{desc: "literal promotion", expr: `1 + 0.5`, in: nil, out: 1.5},
+		{desc: "literal conversion", expr: `Age - 1`, in: alice, out: int64(29)},
+		{desc: "negation", expr: `-Qty`, in: alice, out: -4},
+		{desc: "division", expr: `it / 2`, in: 7, out: 3},
+		{desc: "list", expr: `[Name, Qty]`, in: alice, out: []interface{}{"Alice", 4}},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := MustExpr(tC.expr).Call(ctx, tC.in)
+			require.NoError(t, err)
+			assert.Equal(t, tC.out, res)
+		})
+	}
+}
+
+func TestExprParseErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expr string
+		pos  int
+	}{
+		{expr: ``, pos: 0},
+		{expr: `Age >`, pos: 5},
+		{expr: `Age >= 18 &&`, pos: 12},
+		{expr: `Age # 1`, pos: 4},
+		{expr: `(Age > 1`, pos: 8},
+		{expr: `Name == "abc`, pos: 8},
+		{expr: `Name =~ /abc`, pos: 8},
+		{expr: `Name =~ /(/`, pos: 8},
+		{expr: `Name =~ Other`, pos: 8},
+		{expr: `1 < 2 < 3`, pos: 6},
+		{expr: `Tags[Qty]`, pos: 5},
+		{expr: `Age > 1)`, pos: 7},
+		{expr: `it / /x/`, pos: 5},
+		{expr: `LEN(Name) > 1`, pos: 0},
+		{expr: `Age = 18`, pos: 4},
+		{expr: `Age > 1 and Qty > 1`, pos: 8},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.expr, func(t *testing.T) {
+			t.Parallel()
+			_, err := Expr(tC.expr)
+			var ee *expr.Error
+			require.True(t, errors.As(err, &ee), "%v", err)
+			assert.Equal(t, tC.pos, ee.Pos, "%v", err)
+		})
+	}
+}
+
+type exprKeywords struct {
+	In, Contains, Not, And, Or, Null, Nil, True, False int
+}
+
+func TestExprKeywordFields(t *testing.T) {
+	t.Parallel()
+
+	in := exprKeywords{In: 1, Contains: 2, Not: 3, And: 4, Or: 5, Null: 6, Nil: 7, True: 8, False: 9}
+	for _, s := range []string{
+		`In > 0`,
+		`Contains == 2`,
+		`Not + And == 7`,
+		`Or == 5 && Null == 6`,
+		`Nil < True && False in [9]`,
+	} {
+		res, err := MustExpr(s).Test(ctx, in)
+		require.NoError(t, err, s)
+		assert.True(t, res, s)
+	}
+}
+
+func TestExprRuntimeErrorPosition(t *testing.T) {
+	_, err := MustExpr(`Qty > 1 && Name + Qty > 1`).Test(ctx, alice)
+	var ee *expr.Error
+	require.True(t, errors.As(err, &ee))
+	assert.Equal(t, 16, ee.Pos)
+}
+
+func TestExprInCollections(t *testing.T) {
+	bob := alice
+	bob.Name, bob.Age = "Bob", 12
+	res, err := mixed(alice, bob).
+		Select(MustExpr(`Age >= 18`)).
+		Map(MustExpr(`Name`)).
+		Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice"}, res)
+}
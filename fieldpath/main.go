@@ -0,0 +1,292 @@
+package fieldpath
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type kind int
+
+const (
+	field kind = iota
+	index
+	key
+)
+
+type segment struct {
+	kind  kind
+	raw   string
+	name  string
+	index int
+}
+
+type P struct {
+	raw  string
+	tag  string
+	segs []segment
+}
+
+type Error struct {
+	Path    string
+	Segment string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Segment == "" {
+		return fmt.Sprintf(`path %q: %v`, e.Path, e.Err)
+	}
+	return fmt.Sprintf(`path %q: segment %q: %v`, e.Path, e.Segment, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+var (
+	ErrNil      = errors.New("nil value")
+	ErrNotFound = errors.New("not found")
+	ErrType     = errors.New("cannot traverse type")
+	ErrRange    = errors.New("index out of range")
+)
+
+// Parse accepts paths such as `User.Address[0].City` and `meta["region"]`.
+// Field names also look up string keys when applied to maps.
+func Parse(s string) (*P, error) {
+	p := &P{raw: s}
+	perr := func(pos int, msg string) error {
+		return &Error{Path: s, Err: fmt.Errorf(`%s at position %d`, msg, pos)}
+	}
+	if s == "" {
+		return nil, perr(0, "empty path")
+	}
+
+	for pos := 0; pos < len(s); {
+		switch s[pos] {
+		case '.':
+			if pos == 0 || pos == len(s)-1 {
+				return nil, perr(pos, "unexpected '.'")
+			}
+			pos++
+			if s[pos] == '.' || s[pos] == '[' {
+				return nil, perr(pos, "expected field name")
+			}
+		case '[':
+			end := closingBracket(s, pos)
+			if end < 0 {
+				return nil, perr(pos, "unterminated '['")
+			}
+			inner := s[pos+1 : end]
+			seg := segment{raw: s[pos : end+1]}
+			switch {
+			case inner == "":
+				return nil, perr(pos, "empty brackets")
+			case inner[0] == '"':
+				name, err := strconv.Unquote(inner)
+				if err != nil {
+					return nil, perr(pos+1, "invalid quoted key")
+				}
+				seg.kind, seg.name = key, name
+			case inner[0] == '\'' && len(inner) >= 2 && inner[len(inner)-1] == '\'':
+				seg.kind, seg.name = key, inner[1:len(inner)-1]
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, perr(pos+1, "invalid index")
+				}
+				seg.kind, seg.index = index, n
+			}
+			p.segs = append(p.segs, seg)
+			pos = end + 1
+			if pos < len(s) && s[pos] != '.' && s[pos] != '[' {
+				return nil, perr(pos, "expected '.' or '['")
+			}
+			continue
+		}
+
+		end := pos
+		for end < len(s) && s[end] != '.' && s[end] != '[' {
+			if s[end] == ']' {
+				return nil, perr(end, "unexpected ']'")
+			}
+			end++
+		}
+		if end == pos {
+			continue
+		}
+		p.segs = append(p.segs, segment{kind: field, raw: s[pos:end], name: s[pos:end]})
+		pos = end
+	}
+	return p, nil
+}
+
+func closingBracket(s string, start int) int {
+	var quote byte
+	for i := start + 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func MustParse(s string) *P {
+	p, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Field returns a single-segment path that is not parsed, so name may
+// contain any characters.
+func Field(name string) *P {
+	return &P{raw: name, segs: []segment{{kind: field, raw: name, name: name}}}
+}
+
+// WithTag returns a copy of p that resolves field names through the given
+// struct tag, such as "json", before falling back to Go field names.
+func (p *P) WithTag(tag string) *P {
+	cp := *p
+	cp.tag = tag
+	return &cp
+}
+
+func (p *P) String() string {
+	return p.raw
+}
+
+func (p *P) Get(i interface{}) (interface{}, error) {
+	v := reflect.ValueOf(i)
+	if !v.IsValid() {
+		return nil, &Error{Path: p.raw, Err: ErrNil}
+	}
+	for _, seg := range p.segs {
+		var err error
+		v, err = p.step(v, seg)
+		if err != nil {
+			return nil, &Error{Path: p.raw, Segment: seg.raw, Err: err}
+		}
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	return v.Interface(), nil
+}
+
+func indirect(v reflect.Value) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, ErrNil
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+func (p *P) step(v reflect.Value, seg segment) (reflect.Value, error) {
+	v, err := indirect(v)
+	if err != nil {
+		return v, err
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if seg.kind == index {
+			break
+		}
+		return p.structField(v, seg.name)
+	case reflect.Map:
+		k, err := mapKey(v.Type().Key(), seg)
+		if err != nil {
+			return v, err
+		}
+		e := v.MapIndex(k)
+		if !e.IsValid() {
+			return e, fmt.Errorf(`key %v: %w`, k, ErrNotFound)
+		}
+		return e, nil
+	case reflect.Slice, reflect.Array, reflect.String:
+		if seg.kind != index {
+			break
+		}
+		if seg.index < 0 || seg.index >= v.Len() {
+			return v, fmt.Errorf(`%w: %d with length %d`, ErrRange, seg.index, v.Len())
+		}
+		return v.Index(seg.index), nil
+	}
+	return v, fmt.Errorf(`%w: %v`, ErrType, v.Type())
+}
+
+func (p *P) structField(v reflect.Value, name string) (reflect.Value, error) {
+	sf, ok := p.lookup(v.Type(), name)
+	if !ok {
+		return v, fmt.Errorf(`field %s on %v: %w`, name, v.Type(), ErrNotFound)
+	}
+	if !sf.IsExported() {
+		return v, fmt.Errorf(`field %s on %v is unexported`, name, v.Type())
+	}
+	f, err := v.FieldByIndexErr(sf.Index)
+	if err != nil {
+		return v, fmt.Errorf(`field %s on %v: %w`, name, v.Type(), ErrNil)
+	}
+	return f, nil
+}
+
+func (p *P) lookup(t reflect.Type, name string) (reflect.StructField, bool) {
+	if p.tag != "" {
+		for _, sf := range reflect.VisibleFields(t) {
+			tag, ok := sf.Tag.Lookup(p.tag)
+			if !ok {
+				continue
+			}
+			if n, _, _ := strings.Cut(tag, ","); n == name {
+				return sf, true
+			}
+		}
+	}
+	if name == "" {
+		return reflect.StructField{}, false
+	}
+	return t.FieldByName(name)
+}
+
+func mapKey(t reflect.Type, seg segment) (reflect.Value, error) {
+	var k reflect.Value
+	if seg.kind == index {
+		k = reflect.ValueOf(seg.index)
+	} else {
+		k = reflect.ValueOf(seg.name)
+	}
+	if k.Type().AssignableTo(t) {
+		return k, nil
+	}
+	if t.Kind() != reflect.Interface && k.Type().ConvertibleTo(t) && k.Kind() == t.Kind() {
+		return k.Convert(t), nil
+	}
+	if seg.kind == index && k.CanConvert(t) && isInteger(t.Kind()) {
+		return k.Convert(t), nil
+	}
+	return k, fmt.Errorf(`%w: cannot use %v as key of type %v`, ErrType, k, t)
+}
+
+func isInteger(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package fieldpath
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/samwho/fu/internal/lift"
+)
+
+// Set returns a copy of i with the value at p replaced by v. Every struct,
+// pointer, slice and map along the path is copied, so i is never modified.
+func (p *P) Set(i interface{}, v interface{}) (interface{}, error) {
+	root := reflect.ValueOf(i)
+	if !root.IsValid() {
+		return nil, &Error{Path: p.raw, Err: ErrNil}
+	}
+	r, err := p.set(root, p.segs, v)
+	if err != nil {
+		return nil, err
+	}
+	return r.Interface(), nil
+}
+
+func (p *P) fail(seg segment, err error) error {
+	return &Error{Path: p.raw, Segment: seg.raw, Err: err}
+}
+
+func (p *P) set(v reflect.Value, segs []segment, nv interface{}) (reflect.Value, error) {
+	if len(segs) == 0 {
+		return p.replacement(v.Type(), nv)
+	}
+	seg := segs[0]
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, p.fail(seg, ErrNil)
+		}
+		r, err := p.set(v.Elem(), segs, nv)
+		if err != nil {
+			return v, err
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(r)
+		return cp, nil
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, p.fail(seg, ErrNil)
+		}
+		return p.set(v.Elem(), segs, nv)
+	case reflect.Struct:
+		if seg.kind == index {
+			break
+		}
+		sf, ok := p.lookup(v.Type(), seg.name)
+		if !ok {
+			return v, p.fail(seg, fmt.Errorf(`field %s on %v: %w`, seg.name, v.Type(), ErrNotFound))
+		}
+		if !sf.IsExported() {
+			return v, p.fail(seg, fmt.Errorf(`field %s on %v is unexported`, seg.name, v.Type()))
+		}
+		return p.setField(v, sf.Index, seg, segs[1:], nv)
+	case reflect.Map:
+		k, err := mapKey(v.Type().Key(), seg)
+		if err != nil {
+			return v, p.fail(seg, err)
+		}
+		e := v.MapIndex(k)
+		if !e.IsValid() {
+			if len(segs) > 1 {
+				return v, p.fail(seg, fmt.Errorf(`key %v: %w`, k, ErrNotFound))
+			}
+			e = reflect.Zero(v.Type().Elem())
+		}
+		r, err := p.set(e, segs[1:], nv)
+		if err != nil {
+			return v, err
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len()+1)
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), iter.Value())
+		}
+		cp.SetMapIndex(k, r)
+		return cp, nil
+	case reflect.Slice, reflect.Array:
+		if seg.kind != index {
+			break
+		}
+		if seg.index < 0 || seg.index >= v.Len() {
+			return v, p.fail(seg, fmt.Errorf(`%w: %d with length %d`, ErrRange, seg.index, v.Len()))
+		}
+		r, err := p.set(v.Index(seg.index), segs[1:], nv)
+		if err != nil {
+			return v, err
+		}
+		var cp reflect.Value
+		if v.Kind() == reflect.Slice {
+			cp = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		} else {
+			cp = reflect.New(v.Type()).Elem()
+		}
+		reflect.Copy(cp, v)
+		cp.Index(seg.index).Set(r)
+		return cp, nil
+	}
+	return v, p.fail(seg, fmt.Errorf(`%w: %v`, ErrType, v.Type()))
+}
+
+func (p *P) setField(v reflect.Value, idx []int, seg segment, rest []segment, nv interface{}) (reflect.Value, error) {
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+	f := cp.Field(idx[0])
+	if !f.CanSet() {
+		return v, p.fail(seg, fmt.Errorf(`field %s on %v is not settable`, seg.name, v.Type()))
+	}
+
+	var r reflect.Value
+	var err error
+	switch {
+	case len(idx) == 1:
+		r, err = p.set(f, rest, nv)
+	case f.Kind() == reflect.Ptr:
+		if f.IsNil() {
+			return v, p.fail(seg, fmt.Errorf(`field %s on %v: %w`, seg.name, v.Type(), ErrNil))
+		}
+		r, err = p.setField(f.Elem(), idx[1:], seg, rest, nv)
+		if err == nil {
+			np := reflect.New(f.Type().Elem())
+			np.Elem().Set(r)
+			r = np
+		}
+	default:
+		r, err = p.setField(f, idx[1:], seg, rest, nv)
+	}
+	if err != nil {
+		return v, err
+	}
+	f.Set(r)
+	return cp, nil
+}
+
+// replacement converts nv to t where that loses nothing, so that setting an
+// int64 field to 1 works.
+func (p *P) replacement(t reflect.Type, nv interface{}) (reflect.Value, error) {
+	v, err := lift.Convert(nv, t)
+	if err != nil {
+		return reflect.Value{}, p.fail(p.segs[len(p.segs)-1], fmt.Errorf(`cannot set: %w`, err))
+	}
+	return v, nil
+}
@@ -0,0 +1,94 @@
+package fu
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/mapper"
+)
+
+func FlatMap(ctx context.Context, is []interface{}, f function.F) ([]interface{}, error) {
+	return mapper.Flat(f).Map(ctx, is)
+}
+
+func FlatMapFn(ctx context.Context, is []interface{}, f function.Fn) ([]interface{}, error) {
+	return mapper.FlatFn(f).Map(ctx, is)
+}
+
+func ParallelFlatMap(ctx context.Context, paralellism int, is []interface{}, f function.F) ([]interface{}, error) {
+	return mapper.ParallelFlat(paralellism, f).Map(ctx, is)
+}
+
+func ParallelFlatMapFn(ctx context.Context, paralellism int, is []interface{}, f function.Fn) ([]interface{}, error) {
+	return mapper.ParallelFlat(paralellism, function.New(f)).Map(ctx, is)
+}
+
+// Flatten expands nested slices and arrays up to depth levels deep. A
+// negative depth flattens completely. Strings are never expanded.
+func Flatten(ctx context.Context, is []interface{}, depth int) ([]interface{}, error) {
+	ret := make([]interface{}, 0, len(is))
+	for _, i := range is {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if depth == 0 || !isFlattenable(i) {
+			ret = append(ret, i)
+			continue
+		}
+		inner, err := mapper.Expand(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		inner, err = Flatten(ctx, inner, depth-1)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, inner...)
+	}
+	return ret, nil
+}
+
+func isFlattenable(i interface{}) bool {
+	if i == nil {
+		return false
+	}
+	switch reflect.TypeOf(i).Kind() {
+	case reflect.Slice, reflect.Array:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Collection) FlatMapFn(f function.Fn) *Collection {
+	return c.FlatMap(function.New(f))
+}
+
+func (c *Collection) FlatMap(f function.F) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = FlatMap(c.ctx, c.is, f)
+	return c
+}
+
+func (c *Collection) ParallelFlatMapFn(parallelism int, f function.Fn) *Collection {
+	return c.ParallelFlatMap(parallelism, function.New(f))
+}
+
+func (c *Collection) ParallelFlatMap(parallelism int, f function.F) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = ParallelFlatMap(c.ctx, parallelism, c.is, f)
+	return c
+}
+
+func (c *Collection) Flatten(depth int) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = Flatten(c.ctx, c.is, depth)
+	return c
+}
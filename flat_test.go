@@ -0,0 +1,167 @@
+package fu
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/samwho/fu/function"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var words = function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+	return strings.Fields(i.(string)), nil
+})
+
+func TestFlatMap(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		f    function.Fn
+		in   []interface{}
+		out  []interface{}
+	}{
+		{
+			desc: "interface slice",
+			f: func(ctx context.Context, i interface{}) (interface{}, error) {
+				return []interface{}{i, i}, nil
+			},
+			in:  []interface{}{1, 2},
+			out: []interface{}{1, 1, 2, 2},
+		},
+		{
+			desc: "typed slice",
+			f:    words.Call,
+			in:   []interface{}{"hello world", "", "foo"},
+			out:  []interface{}{"hello", "world", "foo"},
+		},
+		{
+			desc: "seq",
+			f: func(ctx context.Context, i interface{}) (interface{}, error) {
+				return slices.Values([]int{i.(int), i.(int) * 10}), nil
+			},
+			in:  []interface{}{1, 2},
+			out: []interface{}{1, 10, 2, 20},
+		},
+		{
+			desc: "interface seq",
+			f: func(ctx context.Context, i interface{}) (interface{}, error) {
+				return iter.Seq[interface{}](func(yield func(interface{}) bool) {
+					yield(i)
+				}), nil
+			},
+			in:  []interface{}{1, 2},
+			out: []interface{}{1, 2},
+		},
+		{
+			desc: "channel",
+			f: func(ctx context.Context, i interface{}) (interface{}, error) {
+				c := make(chan int, 2)
+				c <- i.(int)
+				c <- i.(int)
+				close(c)
+				return c, nil
+			},
+			in:  []interface{}{1, 2},
+			out: []interface{}{1, 1, 2, 2},
+		},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			mapped, err := FlatMapFn(ctx, tC.in, tC.f)
+			require.NoError(t, err)
+			assert.Equal(t, tC.out, mapped)
+
+			mapped, err = ParallelFlatMapFn(ctx, 4, tC.in, tC.f)
+			require.NoError(t, err)
+			assert.Equal(t, tC.out, mapped)
+		})
+	}
+}
+
+func TestFlatMapNonSequence(t *testing.T) {
+	_, err := FlatMap(ctx, []interface{}{1}, Identity())
+	assert.Error(t, err)
+}
+
+func TestFlatMapNilChan(t *testing.T) {
+	res, err := FlatMap(ctx, []interface{}{1}, function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		var ch chan int
+		return ch, nil
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
+
+func TestFlatMapCancelledSeq(t *testing.T) {
+	forever := func(yield func(int) bool) {
+		for n := 0; yield(n); n++ {
+		}
+	}
+	testCases := []struct {
+		desc string
+		seq  interface{}
+	}{
+		{desc: "reflected", seq: iter.Seq[int](forever)},
+		{desc: "interface", seq: iter.Seq[interface{}](func(yield func(interface{}) bool) {
+			forever(func(n int) bool { return yield(n) })
+		})},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			cctx, cancel := context.WithCancel(ctx)
+			_, err := FlatMap(cctx, []interface{}{1}, function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+				cancel()
+				return tC.seq, nil
+			}))
+			assert.ErrorIs(t, err, context.Canceled)
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	in := []interface{}{1, []interface{}{2, []int{3, 4}}, "five", [][]string{{"six"}}}
+
+	flat, err := Flatten(ctx, in, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, []int{3, 4}, "five", []string{"six"}}, flat)
+
+	flat, err = Flatten(ctx, in, -1)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, "five", "six"}, flat)
+
+	flat, err = Flatten(ctx, in, 0)
+	require.NoError(t, err)
+	assert.Equal(t, in, flat)
+}
+
+func TestCollectionFlatMap(t *testing.T) {
+	result, err := Strings(ctx, []string{"hello world", "foo bar"}).FlatMap(words).Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello", "world", "foo", "bar"}, result)
+}
+
+func TestCollectionParallelFlatMap(t *testing.T) {
+	result, err := Strings(ctx, []string{"hello world", "foo bar"}).ParallelFlatMap(8, words).Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello", "world", "foo", "bar"}, result)
+}
+
+func TestCollectionFlatMapAfterErr(t *testing.T) {
+	_, err := Strings(ctx, []string{"hello world"}).Map(Add(1)).FlatMap(words).Strings()
+	assert.Error(t, err)
+}
+
+func TestCollectionFlatten(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3}).Chunk(2).Flatten(1).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, result)
+}
@@ -8,9 +8,11 @@ import (
 	"strings"
 
 	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/fieldpath"
 	"github.com/samwho/fu/filter"
 	"github.com/samwho/fu/function"
 	"github.com/samwho/fu/mapper"
+	"github.com/samwho/fu/numeric"
 	"github.com/samwho/fu/predicate"
 	"github.com/samwho/fu/reducer"
 )
@@ -102,20 +104,43 @@ func ApplyEnd(bf bifunction.B, j interface{}) function.F {
 }
 
 func Field(name string) function.F {
+	return pathFn(fieldpath.Field(name))
+}
+
+func Path(s string) function.F {
+	p, err := fieldpath.Parse(s)
+	if err != nil {
+		return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+	return pathFn(p)
+}
+
+func TagPath(tag string, s string) function.F {
+	p, err := fieldpath.Parse(s)
+	if err != nil {
+		return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+	return pathFn(p.WithTag(tag))
+}
+
+func pathFn(p *fieldpath.P) function.F {
 	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
-		if reflect.TypeOf(i).Kind() != reflect.Struct {
-			return nil, fmt.Errorf(`cannot get field of non-struct: %v`, i)
-		}
-		val := reflect.ValueOf(i)
-		if !val.IsValid() {
-			return nil, fmt.Errorf(`cannot get field value of invalid value: %v`, i)
-		}
-		field := val.FieldByName(name)
-		zero := reflect.Value{}
-		if field == zero || !field.CanInterface() {
-			return nil, fmt.Errorf(`cannot get field value for name %v on: %v`, name, i)
+		return p.Get(i)
+	})
+}
+
+// Where tests p against the result of kf, as in Where(Path("At"), Before(t)).
+func Where(kf function.F, p predicate.P) predicate.P {
+	return predicate.New(func(ctx context.Context, i interface{}) (bool, error) {
+		k, err := kf.Call(ctx, i)
+		if err != nil {
+			return false, err
 		}
-		return field.Interface(), nil
+		return p.Test(ctx, k)
 	})
 }
 
@@ -183,33 +208,7 @@ func Add(a interface{}) function.F {
 }
 
 func Sum() bifunction.B {
-	return bifunction.New(
-		func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
-			if reflect.TypeOf(a).Kind() != reflect.TypeOf(b).Kind() {
-				return 0, errors.New("types not compatible")
-			}
-
-			switch a.(type) {
-			case int:
-				return b.(int) + a.(int), nil
-			case int32:
-				return b.(int32) + a.(int32), nil
-			case int64:
-				return b.(int64) + a.(int64), nil
-			case uint:
-				return b.(uint) + a.(uint), nil
-			case uint32:
-				return b.(uint32) + a.(uint32), nil
-			case uint64:
-				return b.(uint64) + a.(uint64), nil
-			case float32:
-				return b.(float32) + a.(float32), nil
-			case float64:
-				return b.(float64) + a.(float64), nil
-			default:
-				return 0, errors.New("types not comparable")
-			}
-		})
+	return arithmetic(numeric.Add)
 }
 
 func Sub(a interface{}) function.F {
@@ -217,31 +216,25 @@ func Sub(a interface{}) function.F {
 }
 
 func NegativeSum() bifunction.B {
-	return bifunction.New(
-		func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
-			if reflect.TypeOf(a).Kind() != reflect.TypeOf(b).Kind() {
-				return 0, errors.New("types not compatible")
-			}
+	return arithmetic(numeric.Sub)
+}
+
+func Identity() function.F {
+	return function.New(
+		func(ctx context.Context, a interface{}) (interface{}, error) {
+			return a, nil
+		})
+}
 
-			switch a.(type) {
-			case int:
-				return a.(int) - b.(int), nil
-			case int32:
-				return a.(int32) - b.(int32), nil
-			case int64:
-				return a.(int64) - b.(int64), nil
-			case uint:
-				return a.(uint) - b.(uint), nil
-			case uint32:
-				return a.(uint32) - b.(uint32), nil
-			case uint64:
-				return a.(uint64) - b.(uint64), nil
-			case float32:
-				return a.(float32) - b.(float32), nil
-			case float64:
-				return a.(float64) - b.(float64), nil
+func Len() function.F {
+	return function.New(
+		func(ctx context.Context, a interface{}) (interface{}, error) {
+			v := reflect.ValueOf(a)
+			switch v.Kind() {
+			case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+				return v.Len(), nil
 			default:
-				return false, errors.New("types not comparable")
+				return 0, fmt.Errorf(`cannot get length of: %v`, a)
 			}
 		})
 }
@@ -275,150 +268,69 @@ func Mul(a interface{}) function.F {
 }
 
 func Multiply() bifunction.B {
+	return arithmetic(numeric.Mul)
+}
+
+func arithmetic(op numeric.Op) bifunction.B {
+	return arithmeticMode(numeric.Wrapping, op)
+}
+
+func arithmeticMode(m numeric.Mode, op numeric.Op) bifunction.B {
 	return bifunction.New(
 		func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
-			if reflect.TypeOf(a).Kind() != reflect.TypeOf(b).Kind() {
-				return 0, errors.New("types not compatible")
-			}
-
-			switch a.(type) {
-			case int:
-				return b.(int) * a.(int), nil
-			case int32:
-				return b.(int32) * a.(int32), nil
-			case int64:
-				return b.(int64) * a.(int64), nil
-			case uint:
-				return b.(uint) * a.(uint), nil
-			case uint32:
-				return b.(uint32) * a.(uint32), nil
-			case uint64:
-				return b.(uint64) * a.(uint64), nil
-			case float32:
-				return b.(float32) * a.(float32), nil
-			case float64:
-				return b.(float64) * a.(float64), nil
-			default:
-				return false, errors.New("types not comparable")
-			}
+			return m.Apply(op, a, b)
 		})
 }
 
-func And(ps ...predicate.P) predicate.P {
-	return predicate.New(func(ctx context.Context, a interface{}) (bool, error) {
-		for _, p := range ps {
-			b, err := p.Test(ctx, a)
+func Promoted(bf bifunction.B) bifunction.B {
+	return bifunction.New(
+		func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+			a, b, err := numeric.Promote(a, b)
 			if err != nil {
-				return false, nil
-			}
-
-			if !b {
-				return false, nil
+				return nil, err
 			}
-		}
+			return bf.Call(ctx, a, b)
+		})
+}
 
-		return true, nil
-	})
+func And(ps ...predicate.P) predicate.P {
+	return AtLeast(len(ps), ps...)
 }
 
 func Or(ps ...predicate.P) predicate.P {
-	return predicate.New(func(ctx context.Context, a interface{}) (bool, error) {
-		for _, p := range ps {
-			b, err := p.Test(ctx, a)
-			if err != nil {
-				return false, nil
-			}
-
-			if b {
-				return true, nil
-			}
-		}
-
-		return false, nil
-	})
+	return AtLeast(1, ps...)
 }
 
 func Not(p predicate.P) predicate.P {
 	return predicate.New(func(ctx context.Context, a interface{}) (bool, error) {
 		b, err := p.Test(ctx, a)
-		return !b, err
+		if err != nil {
+			return false, err
+		}
+		return !b, nil
 	})
 }
 
 func Gt(a interface{}) predicate.P {
-	return predicate.New(func(ctx context.Context, b interface{}) (bool, error) {
-		if reflect.TypeOf(a).Kind() != reflect.TypeOf(b).Kind() {
-			return false, errors.New("types not compatible")
-		}
-
-		switch a.(type) {
-		case int:
-			return b.(int) > a.(int), nil
-		case int32:
-			return b.(int32) > a.(int32), nil
-		case int64:
-			return b.(int64) > a.(int64), nil
-		case uint:
-			return b.(uint) > a.(uint), nil
-		case uint32:
-			return b.(uint32) > a.(uint32), nil
-		case uint64:
-			return b.(uint64) > a.(uint64), nil
-		case float32:
-			return b.(float32) > a.(float32), nil
-		case float64:
-			return b.(float64) > a.(float64), nil
-		case string:
-			return b.(string) > a.(string), nil
-		default:
-			return false, errors.New("types not comparable")
-		}
-	})
+	return GtBy(a, Compare())
 }
 
 func Lt(a interface{}) predicate.P {
-	return predicate.New(func(ctx context.Context, b interface{}) (bool, error) {
-		if reflect.TypeOf(a).Kind() != reflect.TypeOf(b).Kind() {
-			return false, errors.New("types not compatible")
-		}
-
-		switch a.(type) {
-		case int:
-			return b.(int) < a.(int), nil
-		case int32:
-			return b.(int32) < a.(int32), nil
-		case int64:
-			return b.(int64) < a.(int64), nil
-		case uint:
-			return b.(uint) < a.(uint), nil
-		case uint32:
-			return b.(uint32) < a.(uint32), nil
-		case uint64:
-			return b.(uint64) < a.(uint64), nil
-		case float32:
-			return b.(float32) < a.(float32), nil
-		case float64:
-			return b.(float64) < a.(float64), nil
-		case string:
-			return b.(string) < a.(string), nil
-		default:
-			return false, errors.New("types not comparable")
-		}
-	})
+	return LtBy(a, Compare())
 }
 
 func Eq(a interface{}) predicate.P {
 	return predicate.New(func(ctx context.Context, b interface{}) (bool, error) {
-		return reflect.DeepEqual(a, b), nil
+		return equal(a, b)
 	})
 }
 
 func Gte(a interface{}) predicate.P {
-	return Or(Gt(a), Eq(a))
+	return GteBy(a, Compare())
 }
 
 func Lte(a interface{}) predicate.P {
-	return Or(Lt(a), Eq(a))
+	return LteBy(a, Compare())
 }
 
 func Neq(a interface{}) predicate.P {
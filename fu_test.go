@@ -3,6 +3,7 @@ package fu
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/samwho/fu/predicate"
 
@@ -18,6 +19,8 @@ var (
 	ctx = context.Background()
 )
 
+type cents int64
+
 func TestBifunctions(t *testing.T) {
 	testCases := []struct {
 		bf          bifunction.B
@@ -62,6 +65,35 @@ func TestBifunctions(t *testing.T) {
 		{bf: NegativeSum(), i: "hello", j: "world", expectedErr: true},
 		{bf: NegativeSum(), i: struct{}{}, j: struct{}{}, expectedErr: true},
 
+		{bf: Sum(), i: int8(3), j: int8(3), expectedRes: int8(6)},
+		{bf: Sum(), i: int16(3), j: int16(3), expectedRes: int16(6)},
+		{bf: Sum(), i: uint8(3), j: uint8(3), expectedRes: uint8(6)},
+		{bf: Sum(), i: uint16(3), j: uint16(3), expectedRes: uint16(6)},
+		{bf: Sum(), i: uintptr(3), j: uintptr(3), expectedRes: uintptr(6)},
+		{bf: Sum(), i: complex(1, 2), j: complex(3, 4), expectedRes: complex(4, 6)},
+		{bf: Sum(), i: complex64(complex(1, 2)), j: complex64(complex(3, 4)), expectedRes: complex64(complex(4, 6))},
+		{bf: Sum(), i: time.Second, j: time.Minute, expectedRes: 61 * time.Second},
+		{bf: Sum(), i: cents(3), j: cents(3), expectedRes: cents(6)},
+		{bf: Sum(), i: cents(3), j: int64(3), expectedErr: true},
+		{bf: Sum(), i: int64(3), j: cents(3), expectedErr: true},
+		{bf: Sum(), i: nil, j: 1, expectedErr: true},
+		{bf: Sum(), i: 1, j: nil, expectedErr: true},
+		{bf: Sum(), i: int8(127), j: int8(1), expectedRes: int8(-128)},
+		{bf: NegativeSum(), i: time.Minute, j: time.Second, expectedRes: 59 * time.Second},
+		{bf: NegativeSum(), i: nil, j: nil, expectedErr: true},
+		{bf: Multiply(), i: cents(3), j: cents(3), expectedRes: cents(9)},
+		{bf: Multiply(), i: uint8(16), j: uint8(16), expectedRes: uint8(0)},
+
+		{bf: Promoted(Sum()), i: int(3), j: float64(0.5), expectedRes: float64(3.5)},
+		{bf: Promoted(Sum()), i: int8(3), j: int64(3), expectedRes: int64(6)},
+		{bf: Promoted(Sum()), i: uint8(3), j: uint(3), expectedRes: uint64(6)},
+		{bf: Promoted(Sum()), i: uint8(3), j: int(-4), expectedRes: int64(-1)},
+		{bf: Promoted(Sum()), i: 1, j: complex(1, 1), expectedRes: complex(2, 1)},
+		{bf: Promoted(Sum()), i: cents(3), j: cents(3), expectedRes: cents(6)},
+		{bf: Promoted(Multiply()), i: float32(2), j: 3, expectedRes: float64(6)},
+		{bf: Promoted(Sum()), i: "a", j: 3, expectedErr: true},
+		{bf: Promoted(Sum()), i: nil, j: 3, expectedErr: true},
+
 		{bf: Join(", "), i: "hello", j: "world", expectedRes: "hello, world"},
 		{bf: Join(", "), i: "hello", j: 1, expectedErr: true},
 		{bf: Join(", "), i: 1, j: 2, expectedErr: true},
@@ -223,6 +255,19 @@ func TestPredicates(t *testing.T) {
 		{p: Lte(float64(0)), in: float64(0), out: true},
 		{p: Lte(float64(0)), in: float64(1), out: false},
 
+		{p: Gt(int8(0)), in: int8(1), out: true},
+		{p: Gt(uint16(0)), in: uint16(1), out: true},
+		{p: Gt(time.Second), in: time.Minute, out: true},
+		{p: Gt(cents(5)), in: cents(4), out: false},
+		{p: Lt(cents(5)), in: cents(4), out: true},
+		{p: Gt("a"), in: "b", out: true},
+		{p: Lt("a"), in: "b", out: false},
+		{p: Gt(cents(5)), in: int64(4), expectedErr: true},
+		{p: Gt(0), in: nil, expectedErr: true},
+		{p: Gt(nil), in: 0, expectedErr: true},
+		{p: Lt(complex(1, 1)), in: complex(1, 1), expectedErr: true},
+		{p: Gt(struct{}{}), in: struct{}{}, expectedErr: true},
+
 		{p: And(Gt(0), Lt(2)), in: 1, out: true},
 		{p: And(Gt(0), Lt(2)), in: 0, out: false},
 		{p: And(Gt(0), Lt(2)), in: 3, out: false},
@@ -1,6 +1,11 @@
 package function
 
-import "context"
+import (
+	"context"
+	"reflect"
+
+	"github.com/samwho/fu/internal/lift"
+)
 
 type F interface {
 	Call(ctx context.Context, i interface{}) (interface{}, error)
@@ -38,3 +43,23 @@ func (mf *multiFn) Call(ctx context.Context, i interface{}) (interface{}, error)
 func Compose(fs ...F) F {
 	return &multiFn{fs: fs}
 }
+
+// Lift adapts an ordinary func such as strings.ToUpper into an F. The func
+// may take a leading context.Context and may return a trailing error.
+func Lift(fn interface{}) (F, error) {
+	l, err := lift.New(fn, 1, reflect.Invalid)
+	if err != nil {
+		return nil, err
+	}
+	return New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return l.Call(ctx, i)
+	}), nil
+}
+
+func MustLift(fn interface{}) F {
+	f, err := Lift(fn)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
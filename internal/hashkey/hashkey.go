@@ -0,0 +1,18 @@
+// Package hashkey checks that values can be used as map keys.
+package hashkey
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Check returns an error if i would panic as a map key. Unlike
+// reflect.Type.Comparable, it looks at the dynamic values of interfaces held
+// in structs and arrays, which are not comparable if they hold slices, maps
+// or funcs.
+func Check(i interface{}) error {
+	if i == nil || reflect.ValueOf(i).Comparable() {
+		return nil
+	}
+	return fmt.Errorf(`%T is not comparable: %v`, i, i)
+}
@@ -0,0 +1,143 @@
+package lift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+type Func struct {
+	v   reflect.Value
+	ctx bool
+	in  []reflect.Type
+	err bool
+}
+
+// New checks that fn is a func taking an optional leading context.Context
+// followed by exactly arity arguments, and returning one value optionally
+// followed by an error. If result is not reflect.Invalid, the returned
+// value must be of that kind.
+func New(fn interface{}, arity int, result reflect.Kind) (*Func, error) {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func {
+		return nil, fmt.Errorf(`cannot lift non-func: %T`, fn)
+	}
+	if v.IsNil() {
+		return nil, errors.New("cannot lift nil func")
+	}
+	t := v.Type()
+	if t.IsVariadic() {
+		return nil, fmt.Errorf(`cannot lift variadic func: %v`, t)
+	}
+
+	f := &Func{v: v}
+	for i := 0; i < t.NumIn(); i++ {
+		f.in = append(f.in, t.In(i))
+	}
+	if len(f.in) > 0 && f.in[0] == contextType {
+		f.ctx = true
+		f.in = f.in[1:]
+	}
+	if len(f.in) != arity {
+		return nil, fmt.Errorf(`cannot lift %v: want %d arguments, got %d`, t, arity, len(f.in))
+	}
+
+	switch t.NumOut() {
+	case 1:
+	case 2:
+		if t.Out(1) != errorType {
+			return nil, fmt.Errorf(`cannot lift %v: second result must be error`, t)
+		}
+		f.err = true
+	default:
+		return nil, fmt.Errorf(`cannot lift %v: want 1 or 2 results, got %d`, t, t.NumOut())
+	}
+	if result != reflect.Invalid && t.Out(0).Kind() != result {
+		return nil, fmt.Errorf(`cannot lift %v: first result must be %v`, t, result)
+	}
+	return f, nil
+}
+
+func (f *Func) Call(ctx context.Context, args ...interface{}) (interface{}, error) {
+	in := make([]reflect.Value, 0, len(args)+1)
+	if f.ctx {
+		in = append(in, reflect.ValueOf(&ctx).Elem())
+	}
+	for idx, a := range args {
+		v, err := Convert(a, f.in[idx])
+		if err != nil {
+			return nil, fmt.Errorf(`argument %d of %v: %w`, idx, f.v.Type(), err)
+		}
+		in = append(in, v)
+	}
+
+	out := f.v.Call(in)
+	if f.err && !out[1].IsNil() {
+		return nil, out[1].Interface().(error)
+	}
+	return out[0].Interface(), nil
+}
+
+func (f *Func) CallBool(ctx context.Context, args ...interface{}) (bool, error) {
+	r, err := f.Call(ctx, args...)
+	if err != nil {
+		return false, err
+	}
+	return reflect.ValueOf(r).Bool(), nil
+}
+
+// Convert converts a to t, allowing assignment, conversion between named
+// types of the same kind and lossless conversion between numeric kinds.
+func Convert(a interface{}, t reflect.Type) (reflect.Value, error) {
+	if a == nil {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, fmt.Errorf(`cannot use nil as %v`, t)
+	}
+	v := reflect.ValueOf(a)
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if v.CanConvert(t) && v.Kind() == t.Kind() {
+		return v.Convert(t), nil
+	}
+	if isNumeric(v.Kind()) && isNumeric(t.Kind()) {
+		c := v.Convert(t)
+		if !isNegative(v) && isNegative(c) || isNegative(v) && !isNegative(c) {
+			return reflect.Value{}, fmt.Errorf(`cannot convert %v (type %T) to %v without loss`, a, a, t)
+		}
+		if c.Convert(v.Type()).Equal(v) {
+			return c, nil
+		}
+		return reflect.Value{}, fmt.Errorf(`cannot convert %v (type %T) to %v without loss`, a, a, t)
+	}
+	return reflect.Value{}, fmt.Errorf(`cannot use %v (type %T) as %v`, a, a, t)
+}
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
+
+func isNumeric(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
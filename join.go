@@ -0,0 +1,77 @@
+package fu
+
+import (
+	"context"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/joiner"
+)
+
+func InnerJoin(ctx context.Context, left []interface{}, right []interface{}, lk function.F, rk function.F, bf bifunction.B) ([]interface{}, error) {
+	return joiner.New(joiner.Inner, lk, rk, bf).Join(ctx, left, right)
+}
+
+func LeftJoin(ctx context.Context, left []interface{}, right []interface{}, lk function.F, rk function.F, bf bifunction.B) ([]interface{}, error) {
+	return joiner.New(joiner.LeftOuter, lk, rk, bf).Join(ctx, left, right)
+}
+
+func RightJoin(ctx context.Context, left []interface{}, right []interface{}, lk function.F, rk function.F, bf bifunction.B) ([]interface{}, error) {
+	return joiner.New(joiner.RightOuter, lk, rk, bf).Join(ctx, left, right)
+}
+
+func FullJoin(ctx context.Context, left []interface{}, right []interface{}, lk function.F, rk function.F, bf bifunction.B) ([]interface{}, error) {
+	return joiner.New(joiner.FullOuter, lk, rk, bf).Join(ctx, left, right)
+}
+
+func SemiJoin(ctx context.Context, left []interface{}, right []interface{}, lk function.F, rk function.F) ([]interface{}, error) {
+	return joiner.New(joiner.Semi, lk, rk, nil).Join(ctx, left, right)
+}
+
+func AntiJoin(ctx context.Context, left []interface{}, right []interface{}, lk function.F, rk function.F) ([]interface{}, error) {
+	return joiner.New(joiner.Anti, lk, rk, nil).Join(ctx, left, right)
+}
+
+func MergeJoin(ctx context.Context, kind joiner.Kind, left []interface{}, right []interface{}, lk function.F, rk function.F, cmp bifunction.B, bf bifunction.B) ([]interface{}, error) {
+	return joiner.Merge(kind, lk, rk, cmp, bf).Join(ctx, left, right)
+}
+
+func (c *Collection) join(o *Collection, j joiner.J) *Collection {
+	if c.err != nil {
+		return c
+	}
+	if o.err != nil {
+		c.err = o.err
+		return c
+	}
+	c.is, c.err = j.Join(c.ctx, c.is, o.is)
+	return c
+}
+
+func (c *Collection) InnerJoin(o *Collection, lk function.F, rk function.F, bf bifunction.B) *Collection {
+	return c.join(o, joiner.New(joiner.Inner, lk, rk, bf))
+}
+
+func (c *Collection) LeftJoin(o *Collection, lk function.F, rk function.F, bf bifunction.B) *Collection {
+	return c.join(o, joiner.New(joiner.LeftOuter, lk, rk, bf))
+}
+
+func (c *Collection) RightJoin(o *Collection, lk function.F, rk function.F, bf bifunction.B) *Collection {
+	return c.join(o, joiner.New(joiner.RightOuter, lk, rk, bf))
+}
+
+func (c *Collection) FullJoin(o *Collection, lk function.F, rk function.F, bf bifunction.B) *Collection {
+	return c.join(o, joiner.New(joiner.FullOuter, lk, rk, bf))
+}
+
+func (c *Collection) SemiJoin(o *Collection, lk function.F, rk function.F) *Collection {
+	return c.join(o, joiner.New(joiner.Semi, lk, rk, nil))
+}
+
+func (c *Collection) AntiJoin(o *Collection, lk function.F, rk function.F) *Collection {
+	return c.join(o, joiner.New(joiner.Anti, lk, rk, nil))
+}
+
+func (c *Collection) MergeJoin(kind joiner.Kind, o *Collection, lk function.F, rk function.F, cmp bifunction.B, bf bifunction.B) *Collection {
+	return c.join(o, joiner.Merge(kind, lk, rk, cmp, bf))
+}
@@ -0,0 +1,136 @@
+package fu
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/joiner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type joinUser struct {
+	ID   int
+	Name string
+}
+
+type joinOrder struct {
+	ID     int
+	UserID int
+}
+
+type joined struct {
+	L interface{}
+	R interface{}
+}
+
+var (
+	joinUsers = []interface{}{
+		joinUser{ID: 1, Name: "alice"},
+		joinUser{ID: 2, Name: "bob"},
+		joinUser{ID: 3, Name: "carol"},
+	}
+	joinOrders = []interface{}{
+		joinOrder{ID: 10, UserID: 1},
+		joinOrder{ID: 11, UserID: 1},
+		joinOrder{ID: 12, UserID: 3},
+		joinOrder{ID: 13, UserID: 4},
+	}
+	joinPair = bifunction.New(func(ctx context.Context, i interface{}, j interface{}) (interface{}, error) {
+		return joined{i, j}, nil
+	})
+)
+
+func TestJoins(t *testing.T) {
+	t.Parallel()
+
+	u1, u2, u3 := joinUsers[0], joinUsers[1], joinUsers[2]
+	o10, o11, o12, o13 := joinOrders[0], joinOrders[1], joinOrders[2], joinOrders[3]
+
+	testCases := []struct {
+		desc string
+		kind joiner.Kind
+		out  []interface{}
+	}{
+		{
+			desc: "inner",
+			kind: joiner.Inner,
+			out:  []interface{}{joined{u1, o10}, joined{u1, o11}, joined{u3, o12}},
+		},
+		{
+			desc: "left outer",
+			kind: joiner.LeftOuter,
+			out:  []interface{}{joined{u1, o10}, joined{u1, o11}, joined{u2, nil}, joined{u3, o12}},
+		},
+		{
+			desc: "right outer",
+			kind: joiner.RightOuter,
+			out:  []interface{}{joined{u1, o10}, joined{u1, o11}, joined{u3, o12}, joined{nil, o13}},
+		},
+		{
+			desc: "full outer",
+			kind: joiner.FullOuter,
+			out:  []interface{}{joined{u1, o10}, joined{u1, o11}, joined{u2, nil}, joined{u3, o12}, joined{nil, o13}},
+		},
+		{
+			desc: "semi",
+			kind: joiner.Semi,
+			out:  []interface{}{u1, u3},
+		},
+		{
+			desc: "anti",
+			kind: joiner.Anti,
+			out:  []interface{}{u2},
+		},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			hashed, err := joiner.New(tC.kind, Field("ID"), Field("UserID"), joinPair).Join(ctx, joinUsers, joinOrders)
+			require.NoError(t, err)
+			assert.Equal(t, tC.out, hashed)
+
+			merged, err := MergeJoin(ctx, tC.kind, joinUsers, joinOrders, Field("ID"), Field("UserID"), Compare(), joinPair)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tC.out, merged)
+		})
+	}
+}
+
+func TestJoinNonComparableKey(t *testing.T) {
+	_, err := InnerJoin(ctx, []interface{}{[]int{1}}, []interface{}{[]int{1}}, Identity(), Identity(), joinPair)
+	assert.Error(t, err)
+
+	nested := []interface{}{joined{L: []int{1}}}
+	_, err = InnerJoin(ctx, nested, nested, Identity(), Identity(), joinPair)
+	assert.Error(t, err)
+	_, err = InnerJoin(ctx, nested, joinUsers, Identity(), Field("ID"), joinPair)
+	assert.Error(t, err)
+}
+
+func TestJoinMissingBifunction(t *testing.T) {
+	_, err := InnerJoin(ctx, joinUsers, joinOrders, Field("ID"), Field("UserID"), nil)
+	assert.Error(t, err)
+}
+
+func TestCollectionInnerJoin(t *testing.T) {
+	users := Ints(ctx, []int{1, 2, 3})
+	orders := Ints(ctx, []int{3, 1, 1})
+	result, err := users.InnerJoin(orders, Identity(), Identity(), Sum()).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 6}, result)
+}
+
+func TestCollectionJoinAfterErr(t *testing.T) {
+	_, err := Ints(ctx, []int{1}).SemiJoin(Strings(ctx, []string{"a"}).Map(Add(1)), Identity(), Identity()).Ints()
+	assert.Error(t, err)
+}
+
+func TestCollectionAntiJoin(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3, 4}).AntiJoin(Ints(ctx, []int{2, 4}), Identity(), Identity()).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 3}, result)
+}
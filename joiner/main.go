@@ -0,0 +1,290 @@
+package joiner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/internal/hashkey"
+)
+
+type J interface {
+	Join(ctx context.Context, left []interface{}, right []interface{}) ([]interface{}, error)
+}
+
+type Kind int
+
+const (
+	Inner Kind = iota
+	LeftOuter
+	RightOuter
+	FullOuter
+	Semi
+	Anti
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Inner:
+		return "inner"
+	case LeftOuter:
+		return "left outer"
+	case RightOuter:
+		return "right outer"
+	case FullOuter:
+		return "full outer"
+	case Semi:
+		return "semi"
+	case Anti:
+		return "anti"
+	default:
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+}
+
+type emitter struct {
+	ctx  context.Context
+	kind Kind
+	bf   bifunction.B
+	ret  []interface{}
+}
+
+func (e *emitter) match(l interface{}, r interface{}) error {
+	switch e.kind {
+	case Inner, LeftOuter, RightOuter, FullOuter:
+		return e.combine(l, r)
+	}
+	return nil
+}
+
+func (e *emitter) leftOnly(l interface{}) error {
+	switch e.kind {
+	case LeftOuter, FullOuter:
+		return e.combine(l, nil)
+	case Anti:
+		e.ret = append(e.ret, l)
+	}
+	return nil
+}
+
+func (e *emitter) rightOnly(r interface{}) error {
+	switch e.kind {
+	case RightOuter, FullOuter:
+		return e.combine(nil, r)
+	}
+	return nil
+}
+
+func (e *emitter) combine(l interface{}, r interface{}) error {
+	v, err := e.bf.Call(e.ctx, l, r)
+	if err != nil {
+		return err
+	}
+	e.ret = append(e.ret, v)
+	return nil
+}
+
+func newEmitter(ctx context.Context, kind Kind, bf bifunction.B) (*emitter, error) {
+	switch kind {
+	case Inner, LeftOuter, RightOuter, FullOuter:
+		if bf == nil {
+			return nil, fmt.Errorf(`%v join requires a bifunction`, kind)
+		}
+	case Semi, Anti:
+	default:
+		return nil, fmt.Errorf(`unknown join kind: %v`, kind)
+	}
+	return &emitter{ctx: ctx, kind: kind, bf: bf}, nil
+}
+
+func keys(ctx context.Context, kf function.F, is []interface{}) ([]interface{}, error) {
+	ks := make([]interface{}, len(is))
+	for idx, i := range is {
+		var err error
+		ks[idx], err = kf.Call(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+type hashJoiner struct {
+	kind Kind
+	lk   function.F
+	rk   function.F
+	bf   bifunction.B
+}
+
+func (h *hashJoiner) Join(ctx context.Context, left []interface{}, right []interface{}) ([]interface{}, error) {
+	e, err := newEmitter(ctx, h.kind, h.bf)
+	if err != nil {
+		return nil, err
+	}
+
+	rks, err := keys(ctx, h.rk, right)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[interface{}][]int, len(right))
+	for idx, k := range rks {
+		if err := hashkey.Check(k); err != nil {
+			return nil, fmt.Errorf(`cannot join on key: %w`, err)
+		}
+		index[k] = append(index[k], idx)
+	}
+
+	matched := make([]bool, len(right))
+	for _, l := range left {
+		k, err := h.lk.Call(ctx, l)
+		if err != nil {
+			return nil, err
+		}
+		if err := hashkey.Check(k); err != nil {
+			return nil, fmt.Errorf(`cannot join on key: %w`, err)
+		}
+
+		idxs, ok := index[k]
+		if !ok {
+			if err := e.leftOnly(l); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if h.kind == Semi {
+			e.ret = append(e.ret, l)
+			continue
+		}
+		for _, idx := range idxs {
+			matched[idx] = true
+			if err := e.match(l, right[idx]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for idx, r := range right {
+		if matched[idx] {
+			continue
+		}
+		if err := e.rightOnly(r); err != nil {
+			return nil, err
+		}
+	}
+	return e.ret, nil
+}
+
+func New(kind Kind, lk function.F, rk function.F, bf bifunction.B) J {
+	return &hashJoiner{kind: kind, lk: lk, rk: rk, bf: bf}
+}
+
+type mergeJoiner struct {
+	kind Kind
+	lk   function.F
+	rk   function.F
+	cmp  bifunction.B
+	bf   bifunction.B
+}
+
+func (m *mergeJoiner) compare(ctx context.Context, a interface{}, b interface{}) (int, error) {
+	c, err := m.cmp.Call(ctx, a, b)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := c.(int)
+	if !ok {
+		return 0, fmt.Errorf(`comparator returned non-int: %v`, c)
+	}
+	return n, nil
+}
+
+func (m *mergeJoiner) Join(ctx context.Context, left []interface{}, right []interface{}) ([]interface{}, error) {
+	e, err := newEmitter(ctx, m.kind, m.bf)
+	if err != nil {
+		return nil, err
+	}
+	lks, err := keys(ctx, m.lk, left)
+	if err != nil {
+		return nil, err
+	}
+	rks, err := keys(ctx, m.rk, right)
+	if err != nil {
+		return nil, err
+	}
+
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		c, err := m.compare(ctx, lks[i], rks[j])
+		if err != nil {
+			return nil, err
+		}
+		if c < 0 {
+			if err := e.leftOnly(left[i]); err != nil {
+				return nil, err
+			}
+			i++
+			continue
+		}
+		if c > 0 {
+			if err := e.rightOnly(right[j]); err != nil {
+				return nil, err
+			}
+			j++
+			continue
+		}
+
+		iend, err := m.runEnd(ctx, lks, i)
+		if err != nil {
+			return nil, err
+		}
+		jend, err := m.runEnd(ctx, rks, j)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range left[i:iend] {
+			if m.kind == Semi {
+				e.ret = append(e.ret, l)
+				continue
+			}
+			for _, r := range right[j:jend] {
+				if err := e.match(l, r); err != nil {
+					return nil, err
+				}
+			}
+		}
+		i, j = iend, jend
+	}
+
+	for ; i < len(left); i++ {
+		if err := e.leftOnly(left[i]); err != nil {
+			return nil, err
+		}
+	}
+	for ; j < len(right); j++ {
+		if err := e.rightOnly(right[j]); err != nil {
+			return nil, err
+		}
+	}
+	return e.ret, nil
+}
+
+func (m *mergeJoiner) runEnd(ctx context.Context, ks []interface{}, start int) (int, error) {
+	end := start + 1
+	for ; end < len(ks); end++ {
+		c, err := m.compare(ctx, ks[start], ks[end])
+		if err != nil {
+			return 0, err
+		}
+		if c != 0 {
+			break
+		}
+	}
+	return end, nil
+}
+
+// Merge expects both inputs to already be sorted by their keys according to
+// cmp, which must return an int less than, equal to or greater than zero.
+func Merge(kind Kind, lk function.F, rk function.F, cmp bifunction.B, bf bifunction.B) J {
+	return &mergeJoiner{kind: kind, lk: lk, rk: rk, cmp: cmp, bf: bf}
+}
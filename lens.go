@@ -0,0 +1,32 @@
+package fu
+
+import (
+	"context"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/fieldpath"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/lens"
+)
+
+func Lens(path string) lens.L {
+	p, err := fieldpath.Parse(path)
+	if err != nil {
+		return lens.New(
+			function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+				return nil, err
+			}),
+			bifunction.New(func(ctx context.Context, i interface{}, j interface{}) (interface{}, error) {
+				return nil, err
+			}))
+	}
+	return lens.Path(p)
+}
+
+func Set(path string, v interface{}) function.F {
+	return lens.Set(Lens(path), v)
+}
+
+func Over(path string, f function.F) function.F {
+	return lens.Over(Lens(path), f)
+}
@@ -0,0 +1,115 @@
+package lens
+
+import (
+	"context"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/fieldpath"
+	"github.com/samwho/fu/function"
+)
+
+type L interface {
+	Get(ctx context.Context, i interface{}) (interface{}, error)
+	Set(ctx context.Context, i interface{}, v interface{}) (interface{}, error)
+}
+
+type lensImpl struct {
+	get function.F
+	set bifunction.B
+}
+
+func (l *lensImpl) Get(ctx context.Context, i interface{}) (interface{}, error) {
+	return l.get.Call(ctx, i)
+}
+
+func (l *lensImpl) Set(ctx context.Context, i interface{}, v interface{}) (interface{}, error) {
+	return l.set.Call(ctx, i, v)
+}
+
+func New(get function.F, set bifunction.B) L {
+	return &lensImpl{get: get, set: set}
+}
+
+type pathLens struct {
+	p *fieldpath.P
+}
+
+func (l *pathLens) Get(ctx context.Context, i interface{}) (interface{}, error) {
+	return l.p.Get(i)
+}
+
+func (l *pathLens) Set(ctx context.Context, i interface{}, v interface{}) (interface{}, error) {
+	return l.p.Set(i, v)
+}
+
+func Path(p *fieldpath.P) L {
+	return &pathLens{p: p}
+}
+
+type composedLens struct {
+	ls []L
+}
+
+func (cl *composedLens) Get(ctx context.Context, i interface{}) (interface{}, error) {
+	for _, l := range cl.ls {
+		var err error
+		i, err = l.Get(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return i, nil
+}
+
+func (cl *composedLens) Set(ctx context.Context, i interface{}, v interface{}) (interface{}, error) {
+	return setAll(ctx, cl.ls, i, v)
+}
+
+func setAll(ctx context.Context, ls []L, i interface{}, v interface{}) (interface{}, error) {
+	if len(ls) == 0 {
+		return v, nil
+	}
+	if len(ls) == 1 {
+		return ls[0].Set(ctx, i, v)
+	}
+	inner, err := ls[0].Get(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+	inner, err = setAll(ctx, ls[1:], inner, v)
+	if err != nil {
+		return nil, err
+	}
+	return ls[0].Set(ctx, i, inner)
+}
+
+// Compose focuses through each lens in turn, outermost first.
+func Compose(ls ...L) L {
+	return &composedLens{ls: ls}
+}
+
+func View(l L) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return l.Get(ctx, i)
+	})
+}
+
+func Set(l L, v interface{}) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return l.Set(ctx, i, v)
+	})
+}
+
+func Over(l L, f function.F) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		v, err := l.Get(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		v, err = f.Call(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		return l.Set(ctx, i, v)
+	})
+}
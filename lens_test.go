@@ -0,0 +1,158 @@
+package fu
+
+import (
+	"testing"
+
+	"github.com/samwho/fu/lens"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lensAddress struct {
+	City string
+}
+
+type LensBase struct {
+	ID int
+}
+
+type lensUser struct {
+	*LensBase
+	Name      string
+	Age       int
+	Addresses []lensAddress
+	Home      *lensAddress
+	Meta      map[string]int
+}
+
+func newLensUser() lensUser {
+	return lensUser{
+		LensBase:  &LensBase{ID: 1},
+		Name:      "alice",
+		Age:       30,
+		Addresses: []lensAddress{{City: "London"}},
+		Home:      &lensAddress{City: "Paris"},
+		Meta:      map[string]int{"visits": 1},
+	}
+}
+
+func TestSet(t *testing.T) {
+	u := newLensUser()
+
+	r, err := Set("Addresses[0].City", "Leeds").Call(ctx, u)
+	require.NoError(t, err)
+	assert.Equal(t, "Leeds", r.(lensUser).Addresses[0].City)
+	assert.Equal(t, "London", u.Addresses[0].City)
+
+	r, err = Set("Home.City", "Lyon").Call(ctx, &u)
+	require.NoError(t, err)
+	assert.Equal(t, "Lyon", r.(*lensUser).Home.City)
+	assert.Equal(t, "Paris", u.Home.City)
+
+	r, err = Set(`Meta["logins"]`, 2).Call(ctx, u)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"visits": 1, "logins": 2}, r.(lensUser).Meta)
+	assert.Equal(t, map[string]int{"visits": 1}, u.Meta)
+
+	r, err = Set("ID", 2).Call(ctx, u)
+	require.NoError(t, err)
+	assert.Equal(t, 2, r.(lensUser).ID)
+	assert.Equal(t, 1, u.ID)
+
+	r, err = Set("Home", nil).Call(ctx, u)
+	require.NoError(t, err)
+	assert.Nil(t, r.(lensUser).Home)
+}
+
+func TestSetErrors(t *testing.T) {
+	u := newLensUser()
+
+	_, err := Set("Age", "old").Call(ctx, u)
+	assert.Error(t, err)
+
+	_, err = Set("Age", nil).Call(ctx, u)
+	assert.Error(t, err)
+
+	_, err = Set("Addresses[1].City", "x").Call(ctx, u)
+	assert.Error(t, err)
+
+	_, err = Set("Nope", 1).Call(ctx, u)
+	assert.Error(t, err)
+
+	_, err = Set("Name", "x").Call(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = Set("Name[", "x").Call(ctx, u)
+	assert.Error(t, err)
+}
+
+func TestOver(t *testing.T) {
+	u := newLensUser()
+	r, err := Over("Age", Add(1)).Call(ctx, u)
+	require.NoError(t, err)
+	assert.Equal(t, 31, r.(lensUser).Age)
+	assert.Equal(t, 30, u.Age)
+
+	_, err = Over("Name", Add(1)).Call(ctx, u)
+	assert.Error(t, err)
+}
+
+type lensCounter struct {
+	N     int64
+	Small int8
+	Ratio float32
+}
+
+func TestSetConverts(t *testing.T) {
+	c := lensCounter{N: 1, Small: 1, Ratio: 1}
+
+	r, err := Over("Small", Apply(1, Promoted(Sum()))).Call(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, int8(2), r.(lensCounter).Small)
+
+	r, err = Set("N", 2).Call(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), r.(lensCounter).N)
+
+	r, err = Set("Small", 100).Call(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, int8(100), r.(lensCounter).Small)
+
+	r, err = Set("Ratio", 0.5).Call(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, float32(0.5), r.(lensCounter).Ratio)
+
+	_, err = Set("Small", 300).Call(ctx, c)
+	assert.Error(t, err)
+
+	_, err = Set("N", 1.5).Call(ctx, c)
+	assert.Error(t, err)
+}
+
+func TestLensCompose(t *testing.T) {
+	u := newLensUser()
+	l := lens.Compose(Lens("Addresses"), Lens("[0]"), Lens("City"))
+
+	city, err := lens.View(l).Call(ctx, u)
+	require.NoError(t, err)
+	assert.Equal(t, "London", city)
+
+	r, err := lens.Over(l, Add(1)).Call(ctx, u)
+	assert.Error(t, err)
+	assert.Nil(t, r)
+
+	r, err = lens.Set(l, "Bath").Call(ctx, u)
+	require.NoError(t, err)
+	assert.Equal(t, "Bath", r.(lensUser).Addresses[0].City)
+	assert.Equal(t, "London", u.Addresses[0].City)
+}
+
+func TestCollectionOver(t *testing.T) {
+	type item struct {
+		Price int
+	}
+	result, err := Lazy(ctx, []interface{}{item{1}, item{2}}).Map(Over("Price", Mul(10))).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{item{10}, item{20}}, result)
+}
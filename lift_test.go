@@ -0,0 +1,112 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type liftUser struct {
+	Name string
+	Age  int
+}
+
+func TestFunctionLift(t *testing.T) {
+	upper, err := function.Lift(strings.ToUpper)
+	require.NoError(t, err)
+	result, err := Strings(ctx, []string{"a", "b"}).Map(upper).Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, result)
+
+	atoi := function.MustLift(strconv.Atoi)
+	n, err := atoi.Call(ctx, "12")
+	require.NoError(t, err)
+	assert.Equal(t, 12, n)
+
+	_, err = atoi.Call(ctx, "twelve")
+	assert.Error(t, err)
+
+	_, err = upper.Call(ctx, 1)
+	assert.EqualError(t, err, "argument 0 of func(string) string: cannot use 1 (type int) as string")
+
+	sqrt := function.MustLift(math.Sqrt)
+	r, err := sqrt.Call(ctx, 4)
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), r)
+
+	_, err = sqrt.Call(ctx, 1<<53+1)
+	assert.Error(t, err)
+
+	id := function.MustLift(func(b byte) byte { return b })
+	r, err = id.Call(ctx, 200)
+	require.NoError(t, err)
+	assert.Equal(t, byte(200), r)
+	for _, a := range []interface{}{256, -1, 1.5} {
+		_, err = id.Call(ctx, a)
+		assert.Error(t, err, "%v", a)
+	}
+}
+
+func TestPredicateLift(t *testing.T) {
+	adult, err := predicate.Lift(func(ctx context.Context, u liftUser) (bool, error) {
+		if u.Age < 0 {
+			return false, errors.New("negative age")
+		}
+		return u.Age >= 18, nil
+	})
+	require.NoError(t, err)
+
+	b, err := adult.Test(ctx, liftUser{Age: 20})
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = adult.Test(ctx, liftUser{Age: -1})
+	assert.Error(t, err)
+
+	_, err = adult.Test(ctx, "bob")
+	assert.Error(t, err)
+
+	_, err = predicate.Lift(strings.ToUpper)
+	assert.Error(t, err)
+}
+
+func TestBifunctionLift(t *testing.T) {
+	bf := bifunction.MustLift(math.Max)
+	r, err := Reduce(ctx, []interface{}{1.5, 3.0, 2}, bf)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, r)
+
+	_, err = bifunction.Lift(strings.ToUpper)
+	assert.Error(t, err)
+}
+
+func TestLiftInvalid(t *testing.T) {
+	testCases := []struct {
+		desc string
+		fn   interface{}
+	}{
+		{desc: "not a func", fn: 1},
+		{desc: "nil func", fn: (func(int) int)(nil)},
+		{desc: "no results", fn: func(int) {}},
+		{desc: "bad second result", fn: func(int) (int, int) { return 0, 0 }},
+		{desc: "variadic", fn: func(...int) int { return 0 }},
+		{desc: "too many args", fn: func(int, int) int { return 0 }},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			_, err := function.Lift(tC.fn)
+			assert.Error(t, err)
+		})
+	}
+}
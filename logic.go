@@ -0,0 +1,117 @@
+package fu
+
+import (
+	"context"
+
+	"github.com/samwho/fu/predicate"
+)
+
+// AtLeast is true when at least n of ps are true. Predicates are tested in
+// order and testing stops as soon as the outcome is known. The first error
+// encountered is returned.
+func AtLeast(n int, ps ...predicate.P) predicate.P {
+	return predicate.New(func(ctx context.Context, a interface{}) (bool, error) {
+		trues := 0
+		for idx, p := range ps {
+			if trues >= n {
+				return true, nil
+			}
+			if trues+len(ps)-idx < n {
+				return false, nil
+			}
+			b, err := p.Test(ctx, a)
+			if err != nil {
+				return false, err
+			}
+			if b {
+				trues++
+			}
+		}
+		return trues >= n, nil
+	})
+}
+
+func None(ps ...predicate.P) predicate.P {
+	return Not(Or(ps...))
+}
+
+// Xor is true when an odd number of ps are true.
+func Xor(ps ...predicate.P) predicate.P {
+	return predicate.New(func(ctx context.Context, a interface{}) (bool, error) {
+		odd := false
+		for _, p := range ps {
+			b, err := p.Test(ctx, a)
+			if err != nil {
+				return false, err
+			}
+			odd = odd != b
+		}
+		return odd, nil
+	})
+}
+
+// ConcurrentAtLeast behaves like AtLeast but tests all of ps at the same
+// time. Once the outcome is known the context passed to the remaining
+// predicates is cancelled and their results, including errors, are ignored.
+func ConcurrentAtLeast(n int, ps ...predicate.P) predicate.P {
+	return predicate.New(func(ctx context.Context, a interface{}) (bool, error) {
+		if n <= 0 {
+			return true, nil
+		}
+		if n > len(ps) {
+			return false, nil
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			b   bool
+			err error
+		}
+		results := make(chan result, len(ps))
+		for _, p := range ps {
+			go func(p predicate.P) {
+				b, err := p.Test(ctx, a)
+				results <- result{b, err}
+			}(p)
+		}
+
+		trues, falses := 0, 0
+		for range ps {
+			var r result
+			select {
+			case r = <-results:
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+			if r.err != nil {
+				return false, r.err
+			}
+			if r.b {
+				trues++
+			} else {
+				falses++
+			}
+			if trues >= n {
+				return true, nil
+			}
+			if len(ps)-falses < n {
+				return false, nil
+			}
+		}
+		return trues >= n, nil
+	})
+}
+
+func ConcurrentAnd(ps ...predicate.P) predicate.P {
+	return ConcurrentAtLeast(len(ps), ps...)
+}
+
+func ConcurrentOr(ps ...predicate.P) predicate.P {
+	return ConcurrentAtLeast(1, ps...)
+}
+
+func ConcurrentNone(ps ...predicate.P) predicate.P {
+	return Not(ConcurrentOr(ps...))
+}
@@ -0,0 +1,110 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/samwho/fu/predicate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	always  = predicate.New(func(ctx context.Context, i interface{}) (bool, error) { return true, nil })
+	never   = predicate.New(func(ctx context.Context, i interface{}) (bool, error) { return false, nil })
+	failing = predicate.New(func(ctx context.Context, i interface{}) (bool, error) { return false, errors.New("failed") })
+)
+
+// blocking only returns once its context is cancelled, and counts how many
+// times that happened.
+func blocking(cancelled *int64) predicate.P {
+	return predicate.New(func(ctx context.Context, i interface{}) (bool, error) {
+		select {
+		case <-ctx.Done():
+			atomic.AddInt64(cancelled, 1)
+			return false, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return true, nil
+		}
+	})
+}
+
+func TestCombinators(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc        string
+		p           predicate.P
+		out         bool
+		expectedErr bool
+	}{
+		{desc: "and", p: And(always, always), out: true},
+		{desc: "and false", p: And(always, never), out: false},
+		{desc: "and empty", p: And(), out: true},
+		{desc: "and error", p: And(always, failing), expectedErr: true},
+		{desc: "and short circuits", p: And(never, failing), out: false},
+		{desc: "or", p: Or(never, always), out: true},
+		{desc: "or false", p: Or(never, never), out: false},
+		{desc: "or empty", p: Or(), out: false},
+		{desc: "or error", p: Or(never, failing), expectedErr: true},
+		{desc: "or short circuits", p: Or(always, failing), out: true},
+		{desc: "not", p: Not(never), out: true},
+		{desc: "not error", p: Not(failing), expectedErr: true},
+		{desc: "none", p: None(never, never), out: true},
+		{desc: "none true", p: None(never, always), out: false},
+		{desc: "none error", p: None(failing), expectedErr: true},
+		{desc: "xor one", p: Xor(always, never), out: true},
+		{desc: "xor two", p: Xor(always, always), out: false},
+		{desc: "xor three", p: Xor(always, always, always), out: true},
+		{desc: "xor error", p: Xor(always, failing), expectedErr: true},
+		{desc: "at least", p: AtLeast(2, always, never, always), out: true},
+		{desc: "at least short", p: AtLeast(2, always, never, never), out: false},
+		{desc: "at least decided early", p: AtLeast(2, always, always, failing), out: true},
+		{desc: "at least impossible early", p: AtLeast(2, never, never, failing), out: false},
+		{desc: "at least zero", p: AtLeast(0, failing), out: true},
+
+		{desc: "concurrent and", p: ConcurrentAnd(always, always), out: true},
+		{desc: "concurrent and false", p: ConcurrentAnd(always, never), out: false},
+		{desc: "concurrent and error", p: ConcurrentAnd(always, failing), expectedErr: true},
+		{desc: "concurrent or", p: ConcurrentOr(never, always), out: true},
+		{desc: "concurrent or empty", p: ConcurrentOr(), out: false},
+		{desc: "concurrent none", p: ConcurrentNone(never, never), out: true},
+		{desc: "concurrent at least", p: ConcurrentAtLeast(2, always, never, always), out: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.p.Test(ctx, nil)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestConcurrentCancelsOnceDecided(t *testing.T) {
+	var cancelled int64
+	b, err := ConcurrentOr(blocking(&cancelled), always, blocking(&cancelled)).Test(ctx, nil)
+	require.NoError(t, err)
+	assert.True(t, b)
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&cancelled) == 2 }, time.Second, time.Millisecond)
+
+	cancelled = 0
+	b, err = ConcurrentAnd(blocking(&cancelled), never).Test(ctx, nil)
+	require.NoError(t, err)
+	assert.False(t, b)
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&cancelled) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestSelectPropagatesPredicateErrors(t *testing.T) {
+	_, err := Ints(ctx, []int{1, 2}).Select(And(Gt(0), Gt("a"))).Ints()
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package mapper
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+
+	"github.com/samwho/fu/function"
+)
+
+type flatMapper struct {
+	m M
+}
+
+func (f *flatMapper) Map(ctx context.Context, is []interface{}) ([]interface{}, error) {
+	expanded, err := f.m.Map(ctx, is)
+	if err != nil {
+		return nil, err
+	}
+	var ret []interface{}
+	for _, e := range expanded {
+		ret = append(ret, e.([]interface{})...)
+	}
+	return ret, nil
+}
+
+func expanding(f function.F) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		r, err := f.Call(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		return Expand(ctx, r)
+	})
+}
+
+// Expand turns a slice, array, channel or iter.Seq into a []interface{}.
+// Channels are drained until closed, and sequences until they end, unless
+// ctx is done first. Nil channels and sequences are empty.
+func Expand(ctx context.Context, i interface{}) ([]interface{}, error) {
+	switch t := i.(type) {
+	case []interface{}:
+		return t, nil
+	case iter.Seq[interface{}]:
+		if t == nil {
+			return nil, nil
+		}
+		var ret []interface{}
+		for v := range t {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			ret = append(ret, v)
+		}
+		return ret, nil
+	case nil:
+		return nil, nil
+	}
+
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		ret := make([]interface{}, v.Len())
+		for idx := range ret {
+			ret[idx] = v.Index(idx).Interface()
+		}
+		return ret, nil
+	case reflect.Chan:
+		if v.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, fmt.Errorf(`cannot receive from send-only channel: %v`, v.Type())
+		}
+		if v.IsNil() {
+			return nil, nil
+		}
+		var ret []interface{}
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: v},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		}
+		for {
+			chosen, r, ok := reflect.Select(cases)
+			if chosen == 1 {
+				return nil, ctx.Err()
+			}
+			if !ok {
+				return ret, nil
+			}
+			ret = append(ret, r.Interface())
+		}
+	case reflect.Func:
+		if !isSeq(v.Type()) {
+			break
+		}
+		if v.IsNil() {
+			return nil, nil
+		}
+		var ret []interface{}
+		var err error
+		yield := reflect.MakeFunc(v.Type().In(0), func(args []reflect.Value) []reflect.Value {
+			if err = ctx.Err(); err != nil {
+				return []reflect.Value{reflect.ValueOf(false)}
+			}
+			ret = append(ret, args[0].Interface())
+			return []reflect.Value{reflect.ValueOf(true)}
+		})
+		v.Call([]reflect.Value{yield})
+		if err != nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+	return nil, fmt.Errorf(`cannot flat map non-sequence: %v`, i)
+}
+
+func isSeq(t reflect.Type) bool {
+	if t.NumIn() != 1 || t.NumOut() != 0 {
+		return false
+	}
+	y := t.In(0)
+	return y.Kind() == reflect.Func &&
+		y.NumIn() == 1 &&
+		y.NumOut() == 1 &&
+		y.Out(0).Kind() == reflect.Bool
+}
+
+func Flat(f function.F) M {
+	return &flatMapper{New(expanding(f))}
+}
+
+func FlatFn(f function.Fn) M {
+	return Flat(function.New(f))
+}
+
+func ParallelFlat(parallelism int, f function.F) M {
+	return &flatMapper{Parallel(parallelism, expanding(f))}
+}
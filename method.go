@@ -0,0 +1,320 @@
+package fu
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/samwho/fu/fieldpath"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/internal/lift"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func Method(name string, args ...interface{}) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		v := reflect.ValueOf(i)
+		if !v.IsValid() {
+			return nil, fmt.Errorf(`cannot call method %s on nil`, name)
+		}
+		m := v.MethodByName(name)
+		if !m.IsValid() && v.Kind() != reflect.Ptr {
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			m = p.MethodByName(name)
+		}
+		if !m.IsValid() {
+			return nil, fmt.Errorf(`no method %s on %T`, name, i)
+		}
+
+		t := m.Type()
+		if t.IsVariadic() && len(args) < t.NumIn()-1 || !t.IsVariadic() && len(args) != t.NumIn() {
+			return nil, fmt.Errorf(`method %s on %T: wrong number of arguments: %d`, name, i, len(args))
+		}
+		in := make([]reflect.Value, len(args))
+		for idx, a := range args {
+			var at reflect.Type
+			if t.IsVariadic() && idx >= t.NumIn()-1 {
+				at = t.In(t.NumIn() - 1).Elem()
+			} else {
+				at = t.In(idx)
+			}
+			var err error
+			in[idx], err = lift.Convert(a, at)
+			if err != nil {
+				return nil, fmt.Errorf(`method %s on %T: argument %d: %w`, name, i, idx, err)
+			}
+		}
+
+		out := m.Call(in)
+		if n := len(out); n > 0 && t.Out(n-1) == errorType {
+			if !out[n-1].IsNil() {
+				return nil, out[n-1].Interface().(error)
+			}
+			out = out[:n-1]
+		}
+		switch len(out) {
+		case 0:
+			return nil, nil
+		case 1:
+			return out[0].Interface(), nil
+		default:
+			t := make(Tuple, len(out))
+			for idx, o := range out {
+				t[idx] = o.Interface()
+			}
+			return t, nil
+		}
+	})
+}
+
+func Pluck(paths ...string) function.F {
+	ps := make([]*fieldpath.P, len(paths))
+	for idx, path := range paths {
+		p, err := fieldpath.Parse(path)
+		if err != nil {
+			return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+				return nil, err
+			})
+		}
+		ps[idx] = p
+	}
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		m := make(map[string]interface{}, len(ps))
+		for _, p := range ps {
+			v, err := p.Get(i)
+			if err != nil {
+				return nil, err
+			}
+			m[p.String()] = v
+		}
+		return m, nil
+	})
+}
+
+type jsonField struct {
+	name      string
+	index     []int
+	tagged    bool
+	omitempty bool
+}
+
+// jsonFields returns the fields of t that encoding/json would use. Untagged
+// embedded structs are flattened, and a name used more than once goes to the
+// shallowest field, or the tagged one of several at the same depth.
+func jsonFields(t reflect.Type) []jsonField {
+	var all []jsonField
+	seen := make(map[reflect.Type]bool)
+	var walk func(t reflect.Type, prefix []int)
+	walk = func(t reflect.Type, prefix []int) {
+		if seen[t] {
+			return
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		for n := 0; n < t.NumField(); n++ {
+			sf := t.Field(n)
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			index := append(append([]int(nil), prefix...), n)
+			name, opts, _ := strings.Cut(tag, ",")
+			if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+				// The fields of an unexported embedded struct are still
+				// promoted, but a nil unexported pointer cannot be filled in.
+				if sf.IsExported() || sf.Type.Kind() == reflect.Struct {
+					walk(ft, index)
+				}
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			f := jsonField{
+				name:      name,
+				index:     index,
+				tagged:    name != "",
+				omitempty: strings.Contains(","+opts+",", ",omitempty,"),
+			}
+			if name == "" {
+				f.name = sf.Name
+			}
+			all = append(all, f)
+		}
+	}
+	walk(t, nil)
+
+	byName := make(map[string][]jsonField)
+	for _, f := range all {
+		byName[f.name] = append(byName[f.name], f)
+	}
+	var fs []jsonField
+	for _, f := range all {
+		if d, ok := dominant(byName[f.name]); ok && slices.Equal(d.index, f.index) {
+			fs = append(fs, f)
+		}
+	}
+	return fs
+}
+
+// dominant returns the field that a name shared by fs belongs to, if any.
+func dominant(fs []jsonField) (jsonField, bool) {
+	depth := len(fs[0].index)
+	for _, f := range fs {
+		depth = min(depth, len(f.index))
+	}
+	var top, tagged []jsonField
+	for _, f := range fs {
+		if len(f.index) == depth {
+			top = append(top, f)
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+	}
+	switch {
+	case len(top) == 1:
+		return top[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	}
+	return jsonField{}, false
+}
+
+func ToMap() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		v := reflect.ValueOf(i)
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, fmt.Errorf(`cannot convert nil to map: %v`, i)
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return nil, fmt.Errorf(`cannot convert non-struct to map: %v`, i)
+		}
+
+		fs := jsonFields(v.Type())
+		m := make(map[string]interface{}, len(fs))
+		for _, f := range fs {
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil {
+				continue
+			}
+			if f.omitempty && fv.IsZero() {
+				continue
+			}
+			m[f.name] = fv.Interface()
+		}
+		return m, nil
+	})
+}
+
+// ToStruct is the inverse of ToMap, converting a map[string]interface{} to a
+// struct, or a pointer to one, of type t.
+func ToStruct(t reflect.Type) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		m, ok := i.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf(`cannot convert non-map to %v: %v`, t, i)
+		}
+		v, err := fromMap(t, m)
+		if err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	})
+}
+
+func fromMap(t reflect.Type, m map[string]interface{}) (reflect.Value, error) {
+	if t.Kind() == reflect.Ptr {
+		v, err := fromMap(t.Elem(), m)
+		if err != nil {
+			return v, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(v)
+		return p, nil
+	}
+	if t.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf(`cannot convert map to non-struct type %v`, t)
+	}
+
+	v := reflect.New(t).Elem()
+	fs := jsonFields(t)
+	names := make(map[string]bool, len(fs))
+	for _, f := range fs {
+		names[f.name] = true
+	}
+	for _, f := range fs {
+		mv, ok := m[f.name]
+		if !ok {
+			var k string
+			if k, ok = foldKey(m, f.name, names); ok {
+				mv = m[k]
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		fv := fieldByIndexAlloc(v, f.index)
+		var cv reflect.Value
+		var err error
+		if sub, isMap := mv.(map[string]interface{}); isMap && isStructish(fv.Type()) {
+			cv, err = fromMap(fv.Type(), sub)
+		} else {
+			cv, err = lift.Convert(mv, fv.Type())
+		}
+		if err != nil {
+			return v, fmt.Errorf(`field %s of %v: %w`, f.name, t, err)
+		}
+		fv.Set(cv)
+	}
+	return v, nil
+}
+
+// foldKey returns the first key of m in sorted order that matches name
+// case-insensitively and is not itself the name of a field.
+func foldKey(m map[string]interface{}, name string, names map[string]bool) (string, bool) {
+	var keys []string
+	for k := range m {
+		if !names[k] && strings.EqualFold(k, name) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+	sort.Strings(keys)
+	return keys[0], true
+}
+
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for idx, i := range index {
+		if idx > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+func isStructish(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
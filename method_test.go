@@ -0,0 +1,204 @@
+package fu
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/samwho/fu/function"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type methodCounter struct {
+	N int
+}
+
+func (c methodCounter) Plus(n int) int {
+	return c.N + n
+}
+
+func (c *methodCounter) Incr() {
+	c.N++
+}
+
+func (c *methodCounter) Div(d int) (int, error) {
+	if d == 0 {
+		return 0, errors.New("divide by zero")
+	}
+	return c.N / d, nil
+}
+
+func (c methodCounter) Sum(ns ...int) int {
+	for _, n := range ns {
+		c.N += n
+	}
+	return c.N
+}
+
+func (c methodCounter) Both() (int, string) {
+	return c.N, "n"
+}
+
+type MapBase struct {
+	ID int `json:"id"`
+}
+
+type mapUser struct {
+	MapBase
+	Name    string  `json:"name"`
+	Email   string  `json:"email,omitempty"`
+	Secret  string  `json:"-"`
+	Score   float64 `json:"score"`
+	Address mapAddress
+	private int
+}
+
+type mapAddress struct {
+	City string `json:"city"`
+}
+
+type mapTagged struct {
+	MapBase `json:"base"`
+	Name    string `json:"name"`
+}
+
+type mapShadowed struct {
+	MapBase
+	ID string `json:"id"`
+}
+
+func TestMethod(t *testing.T) {
+	t.Parallel()
+
+	c := methodCounter{N: 4}
+	testCases := []struct {
+		desc        string
+		f           function.F
+		in          interface{}
+		out         interface{}
+		expectedErr bool
+	}{
+		{desc: "value receiver", f: Method("Plus", 1), in: c, out: 5},
+		{desc: "value receiver via pointer", f: Method("Plus", 1), in: &c, out: 5},
+		{desc: "pointer receiver on value", f: Method("Div", 2), in: c, out: 2},
+		{desc: "pointer receiver error", f: Method("Div", 0), in: c, expectedErr: true},
+		{desc: "no results", f: Method("Incr"), in: &methodCounter{}, out: nil},
+		{desc: "variadic", f: Method("Sum", 1, 2, 3), in: c, out: 10},
+		{desc: "variadic empty", f: Method("Sum"), in: c, out: 4},
+		{desc: "multiple results", f: Method("Both"), in: c, out: Tuple{4, "n"}},
+		{desc: "stdlib", f: Method("String"), in: &strings.Builder{}, out: ""},
+		{desc: "converted argument", f: Method("Plus", int64(1)), in: c, out: 5},
+		{desc: "missing", f: Method("Nope"), in: c, expectedErr: true},
+		{desc: "wrong arity", f: Method("Plus"), in: c, expectedErr: true},
+		{desc: "wrong type", f: Method("Plus", "1"), in: c, expectedErr: true},
+		{desc: "nil", f: Method("Plus", 1), in: nil, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.f.Call(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestPluck(t *testing.T) {
+	u := mapUser{MapBase: MapBase{ID: 1}, Name: "alice", Address: mapAddress{City: "Leeds"}}
+	m, err := Pluck("Name", "Address.City").Call(ctx, u)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "alice", "Address.City": "Leeds"}, m)
+
+	_, err = Pluck("Nope").Call(ctx, u)
+	assert.Error(t, err)
+}
+
+func TestToMap(t *testing.T) {
+	u := mapUser{MapBase: MapBase{ID: 1}, Name: "alice", Secret: "x", Score: 1.5, Address: mapAddress{City: "Leeds"}}
+	m, err := ToMap().Call(ctx, &u)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":      1,
+		"name":    "alice",
+		"score":   1.5,
+		"Address": mapAddress{City: "Leeds"},
+	}, m)
+
+	m, err = ToMap().Call(ctx, mapTagged{MapBase{1}, "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"base": MapBase{1}, "name": "alice"}, m)
+
+	m, err = ToMap().Call(ctx, mapShadowed{MapBase{1}, "x"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "x"}, m)
+
+	_, err = ToMap().Call(ctx, 1)
+	assert.Error(t, err)
+
+	_, err = ToMap().Call(ctx, (*mapUser)(nil))
+	assert.Error(t, err)
+}
+
+func TestToStruct(t *testing.T) {
+	in := map[string]interface{}{
+		"id":      float64(2),
+		"NAME":    "bob",
+		"Secret":  "ignored",
+		"score":   3,
+		"Address": map[string]interface{}{"city": "York"},
+		"unknown": true,
+	}
+
+	u, err := ToStruct(reflect.TypeOf(mapUser{})).Call(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, mapUser{MapBase: MapBase{ID: 2}, Name: "bob", Score: 3, Address: mapAddress{City: "York"}}, u)
+
+	p, err := ToStruct(reflect.TypeOf(&mapUser{})).Call(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", p.(*mapUser).Name)
+
+	tagged, err := ToStruct(reflect.TypeOf(mapTagged{})).Call(ctx, map[string]interface{}{
+		"base": map[string]interface{}{"id": 2},
+		"id":   3,
+		"name": "bob",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, mapTagged{MapBase{2}, "bob"}, tagged)
+
+	for n := 0; n < 20; n++ {
+		u, err = ToStruct(reflect.TypeOf(mapUser{})).Call(ctx, map[string]interface{}{"NAME": "a", "Name": "b", "nAmE": "c"})
+		require.NoError(t, err)
+		assert.Equal(t, "a", u.(mapUser).Name)
+
+		u, err = ToStruct(reflect.TypeOf(mapUser{})).Call(ctx, map[string]interface{}{"NAME": "a", "name": "b"})
+		require.NoError(t, err)
+		assert.Equal(t, "b", u.(mapUser).Name)
+	}
+
+	_, err = ToStruct(reflect.TypeOf(mapUser{})).Call(ctx, map[string]interface{}{"id": 1.5})
+	assert.Error(t, err)
+
+	_, err = ToStruct(reflect.TypeOf(mapUser{})).Call(ctx, map[string]interface{}{"name": 1})
+	assert.Error(t, err)
+
+	_, err = ToStruct(reflect.TypeOf(1)).Call(ctx, in)
+	assert.Error(t, err)
+
+	_, err = ToStruct(reflect.TypeOf(mapUser{})).Call(ctx, 1)
+	assert.Error(t, err)
+}
+
+func TestMapRoundTrip(t *testing.T) {
+	u := mapUser{MapBase: MapBase{ID: 1}, Name: "alice", Email: "a@example.com"}
+	result, err := Lazy(ctx, []interface{}{u}).Map(ToMap()).Map(ToStruct(reflect.TypeOf(mapUser{}))).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{u}, result)
+}
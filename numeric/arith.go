@@ -0,0 +1,371 @@
+package numeric
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/cmplx"
+	"reflect"
+)
+
+type Op int
+
+const (
+	Add Op = iota
+	Sub
+	Mul
+	Div
+	Mod
+	Pow
+	Neg
+	Abs
+)
+
+func (op Op) String() string {
+	switch op {
+	case Add:
+		return "add"
+	case Sub:
+		return "subtract"
+	case Mul:
+		return "multiply"
+	case Div:
+		return "divide"
+	case Mod:
+		return "modulo"
+	case Pow:
+		return "power"
+	case Neg:
+		return "negate"
+	case Abs:
+		return "absolute value"
+	default:
+		return fmt.Sprintf("Op(%d)", int(op))
+	}
+}
+
+func (op Op) unary() bool {
+	return op == Neg || op == Abs
+}
+
+// Mode decides what happens when an integer result does not fit in its type.
+type Mode int
+
+const (
+	Wrapping Mode = iota
+	Checked
+	Saturating
+)
+
+type OverflowError struct {
+	Op   Op
+	Args []interface{}
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf(`%v overflows %T: %v`, e.Op, e.Args[0], e.Args)
+}
+
+func (e *OverflowError) Is(target error) bool {
+	return target == ErrOverflow
+}
+
+// Apply performs op on two values of the same numeric type, including named
+// types such as time.Duration, and returns a value of that type. Integer
+// arithmetic wraps on overflow just as it does in Go.
+func Apply(op Op, a interface{}, b interface{}) (interface{}, error) {
+	return Wrapping.Apply(op, a, b)
+}
+
+func ApplyUnary(op Op, a interface{}) (interface{}, error) {
+	return Wrapping.ApplyUnary(op, a)
+}
+
+func (m Mode) Apply(op Op, a interface{}, b interface{}) (interface{}, error) {
+	if op.unary() {
+		return nil, fmt.Errorf(`%v takes one operand`, op)
+	}
+	av, bv, err := operands(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if IsBig(a) {
+		return bigApply(op, a, b)
+	}
+	return m.apply(op, av, bv)
+}
+
+func (m Mode) ApplyUnary(op Op, a interface{}) (interface{}, error) {
+	if !op.unary() {
+		return nil, fmt.Errorf(`%v takes two operands`, op)
+	}
+	if a == nil {
+		return nil, ErrNil
+	}
+	if IsBig(a) {
+		return bigApply(op, a, nil)
+	}
+	av := reflect.ValueOf(a)
+	return m.apply(op, av, reflect.Zero(av.Type()))
+}
+
+func (m Mode) apply(op Op, av reflect.Value, bv reflect.Value) (interface{}, error) {
+	r := reflect.New(av.Type()).Elem()
+	var overflow bool
+	var high bool
+	var err error
+
+	switch classOf(av.Kind()) {
+	case signed:
+		var x int64
+		x, overflow, high, err = signedOp(op, av.Int(), bv.Int(), av.Type().Bits())
+		if overflow && m == Saturating {
+			x = math.MaxInt64 >> (64 - av.Type().Bits())
+			if !high {
+				x = -x - 1
+			}
+		}
+		r.SetInt(x)
+	case unsigned:
+		var x uint64
+		x, overflow, high, err = unsignedOp(op, av.Uint(), bv.Uint(), av.Type().Bits())
+		if overflow && m == Saturating {
+			x = 0
+			if high {
+				x = math.MaxUint64 >> (64 - av.Type().Bits())
+			}
+		}
+		r.SetUint(x)
+	case float:
+		var x float64
+		x, err = floatOp(op, av.Float(), bv.Float())
+		max := math.MaxFloat64
+		if av.Kind() == reflect.Float32 {
+			max = math.MaxFloat32
+		}
+		finite := !math.IsInf(av.Float(), 0) && !math.IsInf(bv.Float(), 0)
+		if finite && (x > max || x < -max) {
+			overflow, high = true, x > 0
+		}
+		if overflow && m == Saturating {
+			x = math.Copysign(max, x)
+		}
+		r.SetFloat(x)
+	case complexes:
+		if op == Abs {
+			abs := cmplx.Abs(av.Complex())
+			if av.Kind() == reflect.Complex64 {
+				return float32(abs), nil
+			}
+			return abs, nil
+		}
+		var x complex128
+		x, err = complexOp(op, av.Complex(), bv.Complex())
+		r.SetComplex(x)
+	default:
+		return nil, fmt.Errorf(`%w: %v`, ErrNotNumeric, av.Type())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if overflow && m == Checked {
+		args := []interface{}{av.Interface()}
+		if !op.unary() {
+			args = append(args, bv.Interface())
+		}
+		return nil, &OverflowError{Op: op, Args: args}
+	}
+	return r.Interface(), nil
+}
+
+// signedOp computes op in int64 and reports whether the true result lies
+// outside the range of a signed integer of the given bit size, and if so
+// whether it was too high or too low.
+func signedOp(op Op, x int64, y int64, size int) (r int64, overflow bool, high bool, err error) {
+	max := int64(math.MaxInt64 >> (64 - size))
+	min := -max - 1
+	bounds := func(r int64) (int64, bool, bool, error) {
+		if r > max {
+			return r, true, true, nil
+		}
+		if r < min {
+			return r, true, false, nil
+		}
+		return r, false, false, nil
+	}
+
+	switch op {
+	case Add:
+		if y > 0 && x > max-y {
+			return x + y, true, true, nil
+		}
+		if y < 0 && x < min-y {
+			return x + y, true, false, nil
+		}
+		return x + y, false, false, nil
+	case Sub:
+		if y < 0 && x > max+y {
+			return x - y, true, true, nil
+		}
+		if y > 0 && x < min+y {
+			return x - y, true, false, nil
+		}
+		return x - y, false, false, nil
+	case Mul:
+		r := x * y
+		if x != 0 && y != 0 && (r/y != x || x == -1 && y == math.MinInt64 || y == -1 && x == math.MinInt64) {
+			return r, true, (x > 0) == (y > 0), nil
+		}
+		return bounds(r)
+	case Div:
+		if y == 0 {
+			return 0, false, false, ErrDivideByZero
+		}
+		if x == min && y == -1 {
+			return x, true, true, nil
+		}
+		return bounds(x / y)
+	case Mod:
+		if y == 0 {
+			return 0, false, false, ErrDivideByZero
+		}
+		if y == -1 {
+			return 0, false, false, nil
+		}
+		return x % y, false, false, nil
+	case Pow:
+		if y < 0 {
+			return 0, false, false, fmt.Errorf(`cannot raise integer to negative power: %d`, y)
+		}
+		negative := x < 0 && y&1 == 1
+		r := int64(1)
+		var overflowed bool
+		for base := x; y > 0; y >>= 1 {
+			if y&1 == 1 {
+				var o bool
+				r, o, _, _ = signedOp(Mul, r, base, size)
+				overflowed = overflowed || o
+			}
+			if y > 1 {
+				var o bool
+				base, o, _, _ = signedOp(Mul, base, base, size)
+				overflowed = overflowed || o
+			}
+		}
+		if overflowed {
+			return r, true, !negative, nil
+		}
+		return r, false, false, nil
+	case Neg:
+		if x == min {
+			return x, true, true, nil
+		}
+		return -x, false, false, nil
+	case Abs:
+		if x == min {
+			return x, true, true, nil
+		}
+		if x < 0 {
+			return -x, false, false, nil
+		}
+		return x, false, false, nil
+	}
+	return 0, false, false, fmt.Errorf(`unsupported operation: %v`, op)
+}
+
+func unsignedOp(op Op, x uint64, y uint64, size int) (r uint64, overflow bool, high bool, err error) {
+	max := uint64(math.MaxUint64 >> (64 - size))
+
+	switch op {
+	case Add:
+		r, carry := bits.Add64(x, y, 0)
+		return r, carry != 0 || r > max, true, nil
+	case Sub:
+		return x - y, y > x, false, nil
+	case Mul:
+		hi, r := bits.Mul64(x, y)
+		return r, hi != 0 || r > max, true, nil
+	case Div:
+		if y == 0 {
+			return 0, false, false, ErrDivideByZero
+		}
+		return x / y, false, false, nil
+	case Mod:
+		if y == 0 {
+			return 0, false, false, ErrDivideByZero
+		}
+		return x % y, false, false, nil
+	case Pow:
+		r := uint64(1)
+		var overflowed bool
+		for base := x; y > 0; y >>= 1 {
+			if y&1 == 1 {
+				var o bool
+				r, o, _, _ = unsignedOp(Mul, r, base, size)
+				overflowed = overflowed || o
+			}
+			if y > 1 {
+				var o bool
+				base, o, _, _ = unsignedOp(Mul, base, base, size)
+				overflowed = overflowed || o
+			}
+		}
+		return r, overflowed, true, nil
+	case Neg:
+		return -x, x != 0, false, nil
+	case Abs:
+		return x, false, false, nil
+	}
+	return 0, false, false, fmt.Errorf(`unsupported operation: %v`, op)
+}
+
+func floatOp(op Op, x float64, y float64) (float64, error) {
+	switch op {
+	case Add:
+		return x + y, nil
+	case Sub:
+		return x - y, nil
+	case Mul:
+		return x * y, nil
+	case Div:
+		if y == 0 {
+			return 0, ErrDivideByZero
+		}
+		return x / y, nil
+	case Mod:
+		if y == 0 {
+			return 0, ErrDivideByZero
+		}
+		return math.Mod(x, y), nil
+	case Pow:
+		return math.Pow(x, y), nil
+	case Neg:
+		return -x, nil
+	case Abs:
+		return math.Abs(x), nil
+	}
+	return 0, fmt.Errorf(`unsupported operation: %v`, op)
+}
+
+func complexOp(op Op, x complex128, y complex128) (complex128, error) {
+	switch op {
+	case Add:
+		return x + y, nil
+	case Sub:
+		return x - y, nil
+	case Mul:
+		return x * y, nil
+	case Div:
+		if y == 0 {
+			return 0, ErrDivideByZero
+		}
+		return x / y, nil
+	case Pow:
+		return cmplx.Pow(x, y), nil
+	case Neg:
+		return -x, nil
+	}
+	return 0, errors.New("unsupported operation on complex numbers: " + op.String())
+}
@@ -0,0 +1,336 @@
+package numeric
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+func IsBig(i interface{}) bool {
+	switch i.(type) {
+	case *big.Int, *big.Float, *big.Rat:
+		return true
+	default:
+		return false
+	}
+}
+
+func bigNil(i interface{}) bool {
+	return reflect.ValueOf(i).IsNil()
+}
+
+// bigFloatOp runs f, returning ErrNaN if it panics with big.ErrNaN, as
+// big.Float does for Inf-Inf, 0*Inf and Inf/Inf.
+func bigFloatOp(f func() *big.Float) (r interface{}, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			nan, ok := e.(big.ErrNaN)
+			if !ok {
+				panic(e)
+			}
+			r, err = nil, fmt.Errorf(`%w: %s`, ErrNaN, nan.Error())
+		}
+	}()
+	return f(), nil
+}
+
+// bigApply never modifies a or b, always returning a newly allocated value.
+func bigApply(op Op, a interface{}, b interface{}) (interface{}, error) {
+	if bigNil(a) || !op.unary() && bigNil(b) {
+		return nil, ErrNil
+	}
+	switch x := a.(type) {
+	case *big.Int:
+		y, _ := b.(*big.Int)
+		r := new(big.Int)
+		switch op {
+		case Add:
+			return r.Add(x, y), nil
+		case Sub:
+			return r.Sub(x, y), nil
+		case Mul:
+			return r.Mul(x, y), nil
+		case Div:
+			if y.Sign() == 0 {
+				return nil, ErrDivideByZero
+			}
+			return r.Quo(x, y), nil
+		case Mod:
+			if y.Sign() == 0 {
+				return nil, ErrDivideByZero
+			}
+			return r.Rem(x, y), nil
+		case Pow:
+			if y.Sign() < 0 {
+				return nil, fmt.Errorf(`cannot raise integer to negative power: %v`, y)
+			}
+			return r.Exp(x, y, nil), nil
+		case Neg:
+			return r.Neg(x), nil
+		case Abs:
+			return r.Abs(x), nil
+		}
+	case *big.Float:
+		y, _ := b.(*big.Float)
+		r := new(big.Float)
+		switch op {
+		case Add:
+			return bigFloatOp(func() *big.Float { return r.Add(x, y) })
+		case Sub:
+			return bigFloatOp(func() *big.Float { return r.Sub(x, y) })
+		case Mul:
+			return bigFloatOp(func() *big.Float { return r.Mul(x, y) })
+		case Div:
+			if y.Sign() == 0 {
+				return nil, ErrDivideByZero
+			}
+			return bigFloatOp(func() *big.Float { return r.Quo(x, y) })
+		case Neg:
+			return r.Neg(x), nil
+		case Abs:
+			return r.Abs(x), nil
+		}
+	case *big.Rat:
+		y, _ := b.(*big.Rat)
+		r := new(big.Rat)
+		switch op {
+		case Add:
+			return r.Add(x, y), nil
+		case Sub:
+			return r.Sub(x, y), nil
+		case Mul:
+			return r.Mul(x, y), nil
+		case Div:
+			if y.Sign() == 0 {
+				return nil, ErrDivideByZero
+			}
+			return r.Quo(x, y), nil
+		case Neg:
+			return r.Neg(x), nil
+		case Abs:
+			return r.Abs(x), nil
+		}
+	}
+	return nil, fmt.Errorf(`unsupported operation on %T: %v`, a, op)
+}
+
+func bigCompare(a interface{}, b interface{}) (int, error) {
+	if bigNil(a) || bigNil(b) {
+		return 0, ErrNil
+	}
+	switch x := a.(type) {
+	case *big.Int:
+		return x.Cmp(b.(*big.Int)), nil
+	case *big.Float:
+		return x.Cmp(b.(*big.Float)), nil
+	case *big.Rat:
+		return x.Cmp(b.(*big.Rat)), nil
+	}
+	return 0, fmt.Errorf(`%w: %T`, ErrNotOrdered, a)
+}
+
+func ToBigInt(i interface{}) (*big.Int, error) {
+	switch t := i.(type) {
+	case nil:
+		return nil, ErrNil
+	case *big.Int:
+		if t == nil {
+			return nil, ErrNil
+		}
+		return new(big.Int).Set(t), nil
+	case *big.Float:
+		if t == nil {
+			return nil, ErrNil
+		}
+		if !t.IsInt() {
+			return nil, fmt.Errorf(`cannot convert %v to big.Int without loss`, t)
+		}
+		r, _ := t.Int(nil)
+		return r, nil
+	case *big.Rat:
+		if t == nil {
+			return nil, ErrNil
+		}
+		if !t.IsInt() {
+			return nil, fmt.Errorf(`cannot convert %v to big.Int without loss`, t)
+		}
+		return new(big.Int).Set(t.Num()), nil
+	}
+	v := reflect.ValueOf(i)
+	switch classOf(v.Kind()) {
+	case signed:
+		return big.NewInt(v.Int()), nil
+	case unsigned:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case float:
+		f := v.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) || f != math.Trunc(f) {
+			return nil, fmt.Errorf(`cannot convert %v to big.Int without loss`, f)
+		}
+		r, _ := big.NewFloat(f).Int(nil)
+		return r, nil
+	}
+	return nil, fmt.Errorf(`%w: %T`, ErrNotNumeric, i)
+}
+
+func ToBigFloat(i interface{}) (*big.Float, error) {
+	switch t := i.(type) {
+	case nil:
+		return nil, ErrNil
+	case *big.Int:
+		if t == nil {
+			return nil, ErrNil
+		}
+		return new(big.Float).SetInt(t), nil
+	case *big.Float:
+		if t == nil {
+			return nil, ErrNil
+		}
+		return new(big.Float).Copy(t), nil
+	case *big.Rat:
+		if t == nil {
+			return nil, ErrNil
+		}
+		return new(big.Float).SetRat(t), nil
+	}
+	v := reflect.ValueOf(i)
+	switch classOf(v.Kind()) {
+	case signed:
+		return new(big.Float).SetInt64(v.Int()), nil
+	case unsigned:
+		return new(big.Float).SetUint64(v.Uint()), nil
+	case float:
+		f := v.Float()
+		if math.IsNaN(f) {
+			return nil, fmt.Errorf(`cannot convert NaN to big.Float`)
+		}
+		return new(big.Float).SetFloat64(f), nil
+	}
+	return nil, fmt.Errorf(`%w: %T`, ErrNotNumeric, i)
+}
+
+func ToBigRat(i interface{}) (*big.Rat, error) {
+	switch t := i.(type) {
+	case nil:
+		return nil, ErrNil
+	case *big.Int:
+		if t == nil {
+			return nil, ErrNil
+		}
+		return new(big.Rat).SetInt(t), nil
+	case *big.Float:
+		if t == nil {
+			return nil, ErrNil
+		}
+		if t.IsInf() {
+			return nil, fmt.Errorf(`cannot convert %v to big.Rat`, t)
+		}
+		r, _ := t.Rat(nil)
+		return r, nil
+	case *big.Rat:
+		if t == nil {
+			return nil, ErrNil
+		}
+		return new(big.Rat).Set(t), nil
+	}
+	v := reflect.ValueOf(i)
+	switch classOf(v.Kind()) {
+	case signed:
+		return new(big.Rat).SetInt64(v.Int()), nil
+	case unsigned:
+		return new(big.Rat).SetUint64(v.Uint()), nil
+	case float:
+		r := new(big.Rat).SetFloat64(v.Float())
+		if r == nil {
+			return nil, fmt.Errorf(`cannot convert %v to big.Rat`, v.Float())
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf(`%w: %T`, ErrNotNumeric, i)
+}
+
+// FromBig converts a big number to the native numeric type t, returning an
+// error rather than losing precision or overflowing.
+func FromBig(i interface{}, t reflect.Type) (interface{}, error) {
+	if i == nil || !IsBig(i) {
+		return nil, fmt.Errorf(`cannot convert non-big value: %v`, i)
+	}
+	if bigNil(i) {
+		return nil, ErrNil
+	}
+	r := reflect.New(t).Elem()
+	switch classOf(t.Kind()) {
+	case signed:
+		n, err := ToBigInt(i)
+		if err != nil {
+			return nil, err
+		}
+		if !n.IsInt64() || r.OverflowInt(n.Int64()) {
+			return nil, fmt.Errorf(`%w: %v does not fit in %v`, ErrOverflow, i, t)
+		}
+		r.SetInt(n.Int64())
+	case unsigned:
+		n, err := ToBigInt(i)
+		if err != nil {
+			return nil, err
+		}
+		if !n.IsUint64() || r.OverflowUint(n.Uint64()) {
+			return nil, fmt.Errorf(`%w: %v does not fit in %v`, ErrOverflow, i, t)
+		}
+		r.SetUint(n.Uint64())
+	case float:
+		f, err := ToBigFloat(i)
+		if err != nil {
+			return nil, err
+		}
+		x, _ := f.Float64()
+		if math.IsInf(x, 0) && !f.IsInf() || r.OverflowFloat(x) {
+			return nil, fmt.Errorf(`%w: %v does not fit in %v`, ErrOverflow, i, t)
+		}
+		r.SetFloat(x)
+	default:
+		return nil, fmt.Errorf(`%w: %v`, ErrNotNumeric, t)
+	}
+	return r.Interface(), nil
+}
+
+func promoteBig(a interface{}, b interface{}) (interface{}, interface{}, error) {
+	rank := func(i interface{}) int {
+		switch i.(type) {
+		case *big.Int:
+			return 1
+		case *big.Rat:
+			return 2
+		case *big.Float:
+			return 3
+		}
+		if classOf(reflect.TypeOf(i).Kind()) == float {
+			return 2
+		}
+		return 1
+	}
+	r := rank(a)
+	if rank(b) > r {
+		r = rank(b)
+	}
+
+	var conv func(interface{}) (interface{}, error)
+	switch r {
+	case 1:
+		conv = func(i interface{}) (interface{}, error) { return ToBigInt(i) }
+	case 2:
+		conv = func(i interface{}) (interface{}, error) { return ToBigRat(i) }
+	default:
+		conv = func(i interface{}) (interface{}, error) { return ToBigFloat(i) }
+	}
+	x, err := conv(a)
+	if err != nil {
+		return nil, nil, err
+	}
+	y, err := conv(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return x, y, nil
+}
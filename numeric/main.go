@@ -0,0 +1,171 @@
+package numeric
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var (
+	ErrNil          = errors.New("nil operand")
+	ErrIncompatible = errors.New("types not compatible")
+	ErrNotNumeric   = errors.New("non-numeric type")
+	ErrNotOrdered   = errors.New("types not comparable")
+	ErrDivideByZero = errors.New("divide by zero")
+	ErrOverflow     = errors.New("overflow")
+	ErrNaN          = errors.New("not a number")
+)
+
+type class int
+
+const (
+	none class = iota
+	signed
+	unsigned
+	float
+	complexes
+)
+
+func classOf(k reflect.Kind) class {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return signed
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return unsigned
+	case reflect.Float32, reflect.Float64:
+		return float
+	case reflect.Complex64, reflect.Complex128:
+		return complexes
+	default:
+		return none
+	}
+}
+
+func IsNumeric(i interface{}) bool {
+	return i != nil && classOf(reflect.TypeOf(i).Kind()) != none
+}
+
+func operands(a interface{}, b interface{}) (reflect.Value, reflect.Value, error) {
+	if a == nil || b == nil {
+		return reflect.Value{}, reflect.Value{}, ErrNil
+	}
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Type() != bv.Type() {
+		return av, bv, fmt.Errorf(`%w: %T and %T`, ErrIncompatible, a, b)
+	}
+	return av, bv, nil
+}
+
+// Compare orders two values of the same numeric, big or string type, returning
+// -1, 0 or 1.
+func Compare(a interface{}, b interface{}) (int, error) {
+	av, bv, err := operands(a, b)
+	if err != nil {
+		return 0, err
+	}
+	if IsBig(a) {
+		return bigCompare(a, b)
+	}
+	switch classOf(av.Kind()) {
+	case signed:
+		return cmp(av.Int(), bv.Int()), nil
+	case unsigned:
+		return cmp(av.Uint(), bv.Uint()), nil
+	case float:
+		return cmp(av.Float(), bv.Float()), nil
+	}
+	if av.Kind() == reflect.String {
+		return cmp(av.String(), bv.String()), nil
+	}
+	return 0, fmt.Errorf(`%w: %T`, ErrNotOrdered, a)
+}
+
+func cmp[T int64 | uint64 | float64 | string](x T, y T) int {
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Promote converts a and b to a common type so that mixed-type arithmetic is
+// possible. Values of the same type are returned unchanged. Otherwise both
+// become complex128 if either is complex, float64 if either is a float,
+// uint64 if both are unsigned and int64 if not, unless an unsigned value is
+// too large for an int64, when both become *big.Int. If either is a *big.Int,
+// *big.Rat or *big.Float, both become the widest big type involved.
+func Promote(a interface{}, b interface{}) (interface{}, interface{}, error) {
+	if a == nil || b == nil {
+		return nil, nil, ErrNil
+	}
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Type() == bv.Type() {
+		return a, b, nil
+	}
+	if IsBig(a) && (IsBig(b) || IsNumeric(b)) || IsBig(b) && IsNumeric(a) {
+		return promoteBig(a, b)
+	}
+	ac, bc := classOf(av.Kind()), classOf(bv.Kind())
+	if ac == none || bc == none {
+		return nil, nil, fmt.Errorf(`%w: %T and %T`, ErrIncompatible, a, b)
+	}
+
+	var t reflect.Type
+	switch {
+	case ac == complexes || bc == complexes:
+		t = reflect.TypeOf(complex128(0))
+	case ac == float || bc == float:
+		t = reflect.TypeOf(float64(0))
+	case ac == unsigned && bc == unsigned:
+		t = reflect.TypeOf(uint64(0))
+	case ac == unsigned && av.Uint() > math.MaxInt64 || bc == unsigned && bv.Uint() > math.MaxInt64:
+		return promoteBig(a, b)
+	default:
+		t = reflect.TypeOf(int64(0))
+	}
+	return convert(av, t), convert(bv, t), nil
+}
+
+func convert(v reflect.Value, t reflect.Type) interface{} {
+	if t.Kind() == reflect.Complex128 && classOf(v.Kind()) != complexes {
+		var f float64
+		switch classOf(v.Kind()) {
+		case signed:
+			f = float64(v.Int())
+		case unsigned:
+			f = float64(v.Uint())
+		case float:
+			f = v.Float()
+		}
+		return complex(f, 0)
+	}
+	return v.Convert(t).Interface()
+}
+
+func ToFloat64(i interface{}) (float64, error) {
+	if i == nil {
+		return 0, ErrNil
+	}
+	if IsBig(i) {
+		f, err := ToBigFloat(i)
+		if err != nil {
+			return 0, err
+		}
+		x, _ := f.Float64()
+		return x, nil
+	}
+	v := reflect.ValueOf(i)
+	switch classOf(v.Kind()) {
+	case signed:
+		return float64(v.Int()), nil
+	case unsigned:
+		return float64(v.Uint()), nil
+	case float:
+		return v.Float(), nil
+	}
+	return 0, fmt.Errorf(`%w: %T`, ErrNotNumeric, i)
+}
@@ -0,0 +1,188 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/numeric"
+	"github.com/samwho/fu/predicate"
+)
+
+// Comparer is implemented by types with their own ordering. Compare returns
+// a negative number, zero or a positive number when the receiver is less
+// than, equal to or greater than other.
+//
+// Types with a typed method of the form Compare(T) int, such as time.Time
+// and netip.Addr, are recognised as well.
+type Comparer interface {
+	Compare(other interface{}) int
+}
+
+func compare(a interface{}, b interface{}) (int, error) {
+	if a == nil || b == nil {
+		return 0, numeric.ErrNil
+	}
+	if c, ok := a.(Comparer); ok {
+		return c.Compare(b), nil
+	}
+	if m := reflect.ValueOf(a).MethodByName("Compare"); m.IsValid() {
+		t := m.Type()
+		if t.NumIn() == 1 && !t.IsVariadic() && t.NumOut() == 1 && t.Out(0).Kind() == reflect.Int {
+			bv := reflect.ValueOf(b)
+			if !bv.Type().AssignableTo(t.In(0)) {
+				return 0, fmt.Errorf(`%w: %T and %T`, numeric.ErrIncompatible, a, b)
+			}
+			return int(m.Call([]reflect.Value{bv})[0].Int()), nil
+		}
+	}
+	return numeric.Compare(a, b)
+}
+
+func Compare() bifunction.B {
+	return bifunction.New(func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+		return compare(a, b)
+	})
+}
+
+func Reverse(cmp bifunction.B) bifunction.B {
+	return bifunction.New(func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+		return cmp.Call(ctx, b, a)
+	})
+}
+
+func CompareBy(kf function.F, cmp bifunction.B) bifunction.B {
+	return bifunction.New(func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+		ka, err := kf.Call(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		kb, err := kf.Call(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		return cmp.Call(ctx, ka, kb)
+	})
+}
+
+func callCompare(ctx context.Context, cmp bifunction.B, a interface{}, b interface{}) (int, error) {
+	r, err := cmp.Call(ctx, a, b)
+	if err != nil {
+		return 0, err
+	}
+	c, ok := r.(int)
+	if !ok {
+		return 0, fmt.Errorf(`comparator returned non-int: %v`, r)
+	}
+	return c, nil
+}
+
+func ordered(a interface{}, cmp bifunction.B, test func(int) bool) predicate.P {
+	return predicate.New(func(ctx context.Context, b interface{}) (bool, error) {
+		c, err := callCompare(ctx, cmp, b, a)
+		if err != nil {
+			return false, err
+		}
+		return test(c), nil
+	})
+}
+
+func GtBy(a interface{}, cmp bifunction.B) predicate.P {
+	return ordered(a, cmp, func(c int) bool { return c > 0 })
+}
+
+func LtBy(a interface{}, cmp bifunction.B) predicate.P {
+	return ordered(a, cmp, func(c int) bool { return c < 0 })
+}
+
+func GteBy(a interface{}, cmp bifunction.B) predicate.P {
+	return ordered(a, cmp, func(c int) bool { return c >= 0 })
+}
+
+func LteBy(a interface{}, cmp bifunction.B) predicate.P {
+	return ordered(a, cmp, func(c int) bool { return c <= 0 })
+}
+
+// Between is inclusive of both lo and hi.
+func Between(lo interface{}, hi interface{}) predicate.P {
+	return BetweenBy(lo, hi, Compare())
+}
+
+func BetweenBy(lo interface{}, hi interface{}, cmp bifunction.B) predicate.P {
+	return And(GteBy(lo, cmp), LteBy(hi, cmp))
+}
+
+func Sort(ctx context.Context, is []interface{}, cmp bifunction.B) ([]interface{}, error) {
+	sorted := make([]interface{}, len(is))
+	copy(sorted, is)
+	var err error
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		var c int
+		c, err = callCompare(ctx, cmp, sorted[i], sorted[j])
+		return c < 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sorted, nil
+}
+
+var ErrEmpty = errors.New("empty input")
+
+func Min(ctx context.Context, is []interface{}, cmp bifunction.B) (interface{}, error) {
+	return extreme(ctx, is, cmp, func(c int) bool { return c < 0 })
+}
+
+func Max(ctx context.Context, is []interface{}, cmp bifunction.B) (interface{}, error) {
+	return extreme(ctx, is, cmp, func(c int) bool { return c > 0 })
+}
+
+func extreme(ctx context.Context, is []interface{}, cmp bifunction.B, better func(int) bool) (interface{}, error) {
+	if len(is) == 0 {
+		return nil, ErrEmpty
+	}
+	ret := is[0]
+	for _, i := range is[1:] {
+		c, err := callCompare(ctx, cmp, i, ret)
+		if err != nil {
+			return nil, err
+		}
+		if better(c) {
+			ret = i
+		}
+	}
+	return ret, nil
+}
+
+func (c *Collection) Sort(cmp bifunction.B) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = Sort(c.ctx, c.is, cmp)
+	return c
+}
+
+func (c *Collection) SortBy(kf function.F) *Collection {
+	return c.Sort(CompareBy(kf, Compare()))
+}
+
+func (c *Collection) Min(cmp bifunction.B) (interface{}, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return Min(c.ctx, c.is, cmp)
+}
+
+func (c *Collection) Max(cmp bifunction.B) (interface{}, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return Max(c.ctx, c.is, cmp)
+}
@@ -0,0 +1,130 @@
+package fu
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type version struct {
+	major, minor int
+}
+
+func (v version) Compare(other interface{}) int {
+	o := other.(version)
+	if v.major != o.major {
+		return v.major - o.major
+	}
+	return v.minor - o.minor
+}
+
+func TestOrderingPredicates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	sameInstant := now.In(time.FixedZone("x", 3600))
+	byLength := CompareBy(Len(), Compare())
+
+	testCases := []struct {
+		desc        string
+		p           predicate.P
+		in          interface{}
+		out         bool
+		expectedErr bool
+	}{
+		{desc: "time gt", p: Gt(now), in: later, out: true},
+		{desc: "time lt", p: Lt(now), in: later, out: false},
+		{desc: "time gte same instant", p: Gte(now), in: sameInstant, out: true},
+		{desc: "time lte same instant", p: Lte(now), in: sameInstant, out: true},
+		{desc: "duration", p: Gt(time.Second), in: time.Minute, out: true},
+		{desc: "netip", p: Lt(netip.MustParseAddr("10.0.0.2")), in: netip.MustParseAddr("10.0.0.1"), out: true},
+		{desc: "comparer", p: Gt(version{1, 2}), in: version{1, 10}, out: true},
+		{desc: "comparer lte", p: Lte(version{1, 2}), in: version{1, 2}, out: true},
+		{desc: "time vs int", p: Gt(now), in: 1, expectedErr: true},
+		{desc: "nil", p: Gt(now), in: nil, expectedErr: true},
+		{desc: "between", p: Between(1, 3), in: 3, out: true},
+		{desc: "between low", p: Between(1, 3), in: 0, out: false},
+		{desc: "between times", p: Between(now, later), in: now.Add(time.Minute), out: true},
+		{desc: "gt by", p: GtBy("aa", byLength), in: "b", out: false},
+		{desc: "lt by", p: LtBy("aa", byLength), in: "b", out: true},
+		{desc: "gte by", p: GteBy("aa", byLength), in: "bb", out: true},
+		{desc: "lte by", p: LteBy("aa", byLength), in: "bbb", out: false},
+		{desc: "between by", p: BetweenBy("a", "ccc", byLength), in: "zz", out: true},
+		{desc: "reverse", p: GtBy(1, Reverse(Compare())), in: 0, out: true},
+		{desc: "bad comparator", p: GtBy(1, Sum()), in: "a", expectedErr: true},
+		{desc: "non-int comparator", p: GtBy(1.0, Sum()), in: 1.0, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.p.Test(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	sorted, err := Sort(ctx, []interface{}{3, 1, 2}, Compare())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, sorted)
+
+	sorted, err = Sort(ctx, []interface{}{version{2, 0}, version{1, 5}}, Reverse(Compare()))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{version{2, 0}, version{1, 5}}, sorted)
+
+	_, err = Sort(ctx, []interface{}{3, "a"}, Compare())
+	assert.Error(t, err)
+}
+
+func TestMinMax(t *testing.T) {
+	now := time.Now()
+	is := []interface{}{now, now.Add(-time.Hour), now.Add(time.Hour)}
+
+	min, err := Min(ctx, is, Compare())
+	require.NoError(t, err)
+	assert.Equal(t, is[1], min)
+
+	max, err := Max(ctx, is, Compare())
+	require.NoError(t, err)
+	assert.Equal(t, is[2], max)
+
+	_, err = Min(ctx, nil, Compare())
+	assert.ErrorIs(t, err, ErrEmpty)
+}
+
+func TestCollectionSort(t *testing.T) {
+	result, err := Strings(ctx, []string{"ccc", "a", "bb"}).SortBy(Len()).Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "bb", "ccc"}, result)
+
+	result, err = Strings(ctx, []string{"b", "C", "a"}).Sort(CompareBy(function.MustLift(strings.ToLower), Compare())).Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "C"}, result)
+}
+
+func TestCollectionMinMax(t *testing.T) {
+	min, err := Ints(ctx, []int{3, 1, 2}).Min(Compare())
+	require.NoError(t, err)
+	assert.Equal(t, 1, min)
+
+	max, err := Ints(ctx, []int{3, 1, 2}).Max(Compare())
+	require.NoError(t, err)
+	assert.Equal(t, 3, max)
+
+	_, err = Strings(ctx, []string{"a"}).Map(Add(1)).Max(Compare())
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package fu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samwho/fu/fieldpath"
+	"github.com/samwho/fu/function"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pathAddress struct {
+	City string `json:"city"`
+}
+
+type pathUser struct {
+	Name      string                 `json:"name,omitempty"`
+	Addresses []*pathAddress         `json:"addresses"`
+	Meta      map[string]interface{} `json:"meta"`
+	Scores    map[int]float64
+	Manager   *pathUser
+	Any       interface{}
+	secret    string
+}
+
+func TestPaths(t *testing.T) {
+	t.Parallel()
+
+	u := pathUser{
+		Name:      "alice",
+		Addresses: []*pathAddress{{City: "London"}, nil},
+		Meta:      map[string]interface{}{"region": "eu", "tags": []string{"vip"}},
+		Scores:    map[int]float64{1: 0.5},
+		Any:       &pathAddress{City: "Paris"},
+		secret:    "shh",
+	}
+
+	testCases := []struct {
+		desc        string
+		f           function.F
+		in          interface{}
+		out         interface{}
+		expectedErr bool
+	}{
+		{desc: "field", f: Path("Name"), in: u, out: "alice"},
+		{desc: "pointer", f: Path("Name"), in: &u, out: "alice"},
+		{desc: "nested", f: Path("Addresses[0].City"), in: u, out: "London"},
+		{desc: "double quoted key", f: Path(`Meta["region"]`), in: u, out: "eu"},
+		{desc: "single quoted key", f: Path(`Meta['region']`), in: u, out: "eu"},
+		{desc: "map field", f: Path("Meta.tags[0]"), in: u, out: "vip"},
+		{desc: "int key", f: Path("Scores[1]"), in: u, out: 0.5},
+		{desc: "interface", f: Path("Any.City"), in: u, out: "Paris"},
+		{desc: "leading index", f: Path("[1]"), in: []int{1, 2}, out: 2},
+		{desc: "nil field", f: Path("Manager"), in: u, out: (*pathUser)(nil)},
+		{desc: "json tag", f: TagPath("json", "addresses[0].city"), in: u, out: "London"},
+		{desc: "json tag with options", f: TagPath("json", "name"), in: u, out: "alice"},
+		{desc: "json tag fallback", f: TagPath("json", "Scores[1]"), in: u, out: 0.5},
+
+		{desc: "nil input", f: Path("Name"), in: nil, expectedErr: true},
+		{desc: "nil pointer", f: Path("Manager.Name"), in: u, expectedErr: true},
+		{desc: "nil element", f: Path("Addresses[1].City"), in: u, expectedErr: true},
+		{desc: "out of range", f: Path("Addresses[2]"), in: u, expectedErr: true},
+		{desc: "missing key", f: Path(`Meta["nope"]`), in: u, expectedErr: true},
+		{desc: "missing field", f: Path("Nope"), in: u, expectedErr: true},
+		{desc: "unexported", f: Path("secret"), in: u, expectedErr: true},
+		{desc: "index on struct", f: Path("[0]"), in: u, expectedErr: true},
+		{desc: "field on string", f: Path("Name.Foo"), in: u, expectedErr: true},
+		{desc: "parse error", f: Path("Addresses[0"), in: u, expectedErr: true},
+		{desc: "empty", f: Path(""), in: u, expectedErr: true},
+		{desc: "double dot", f: Path("a..b"), in: u, expectedErr: true},
+		{desc: "trailing garbage", f: Path("a[0]b"), in: u, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.f.Call(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestPathErrorSegment(t *testing.T) {
+	_, err := Path("Addresses[1].City").Call(ctx, pathUser{Addresses: []*pathAddress{nil, nil}})
+	var perr *fieldpath.Error
+	require.True(t, errors.As(err, &perr))
+	assert.Equal(t, "City", perr.Segment)
+	assert.ErrorIs(t, err, fieldpath.ErrNil)
+}
+
+func TestFieldNil(t *testing.T) {
+	_, err := Field("A").Call(ctx, nil)
+	assert.Error(t, err)
+
+	a, err := Field("A").Call(ctx, &struct{ A int }{1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, a)
+}
@@ -0,0 +1,203 @@
+package fu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+)
+
+// Stage is one step of a Pipeline. In JSON it is an object whose single
+// operation key names a registered function, with optional arguments:
+//
+//	{"map": "add", "args": [1]}
+//	{"parallel_map": "upper", "parallelism": 4}
+//	{"select": "expr", "args": ["Age >= 18"]}
+//	{"reduce": "sum"}
+type Stage struct {
+	Op          string
+	Func        string
+	Args        []interface{}
+	Parallelism int
+}
+
+var stageOps = map[string]bool{"map": true, "parallel_map": true, "select": true, "reject": true, "reduce": true}
+
+func (s Stage) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	write := func(k string, v interface{}) error {
+		bs, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		} else {
+			b.WriteByte('{')
+		}
+		fmt.Fprintf(&b, "%q:%s", k, bs)
+		return nil
+	}
+	if err := write(s.Op, s.Func); err != nil {
+		return nil, err
+	}
+	if len(s.Args) > 0 {
+		if err := write("args", s.Args); err != nil {
+			return nil, err
+		}
+	}
+	if s.Parallelism != 0 {
+		if err := write("parallelism", s.Parallelism); err != nil {
+			return nil, err
+		}
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+func (s *Stage) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*s = Stage{}
+	for k, v := range m {
+		var err error
+		switch {
+		case k == "args":
+			d := json.NewDecoder(bytes.NewReader(v))
+			d.UseNumber()
+			err = d.Decode(&s.Args)
+		case k == "parallelism":
+			err = json.Unmarshal(v, &s.Parallelism)
+		case stageOps[k]:
+			if s.Op != "" {
+				return fmt.Errorf(`stage has both %q and %q`, s.Op, k)
+			}
+			s.Op = k
+			err = json.Unmarshal(v, &s.Func)
+		default:
+			return fmt.Errorf(`unknown stage key %q`, k)
+		}
+		if err != nil {
+			return fmt.Errorf(`stage %q: %w`, k, err)
+		}
+	}
+	if s.Op == "" {
+		return fmt.Errorf(`stage has no operation, want one of map, parallel_map, select, reject or reduce`)
+	}
+	return nil
+}
+
+type PipelineError struct {
+	Stage int
+	Op    string
+	Func  string
+	Err   error
+}
+
+func (e *PipelineError) Error() string {
+	return fmt.Sprintf(`pipeline stage %d (%s %q): %v`, e.Stage, e.Op, e.Func, e.Err)
+}
+
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+// Pipeline is a validated list of Stages whose functions have been looked up
+// in a Registry. It marshals back to the JSON it was built from.
+type Pipeline struct {
+	stages []Stage
+	fs     []interface{}
+}
+
+// Pipeline looks up and checks every stage, so that a Pipeline that builds
+// successfully only fails at run time if its functions do.
+func (r *Registry) Pipeline(stages ...Stage) (*Pipeline, error) {
+	p := &Pipeline{stages: append([]Stage(nil), stages...)}
+	for n, s := range stages {
+		fail := func(err error) (*Pipeline, error) {
+			return nil, &PipelineError{Stage: n, Op: s.Op, Func: s.Func, Err: err}
+		}
+		if !stageOps[s.Op] {
+			return fail(fmt.Errorf(`unknown operation`))
+		}
+		if s.Op == "reduce" && n != len(stages)-1 {
+			return fail(fmt.Errorf(`reduce must be the last stage`))
+		}
+		if s.Op == "parallel_map" && s.Parallelism < 1 {
+			return fail(fmt.Errorf(`parallelism must be at least 1, got %d`, s.Parallelism))
+		}
+		if s.Op != "parallel_map" && s.Parallelism != 0 {
+			return fail(fmt.Errorf(`parallelism only applies to parallel_map`))
+		}
+		f, err := r.Lookup(s.Func, s.Args...)
+		if err != nil {
+			return fail(err)
+		}
+		ok := false
+		switch s.Op {
+		case "map", "parallel_map":
+			_, ok = f.(function.F)
+		case "select", "reject":
+			_, ok = f.(predicate.P)
+		case "reduce":
+			_, ok = f.(bifunction.B)
+		}
+		if !ok {
+			return fail(fmt.Errorf(`cannot use %T with %s`, f, s.Op))
+		}
+		p.fs = append(p.fs, f)
+	}
+	return p, nil
+}
+
+// ParsePipeline builds a Pipeline from a JSON array of Stages.
+func (r *Registry) ParsePipeline(data []byte) (*Pipeline, error) {
+	var stages []Stage
+	if err := json.Unmarshal(data, &stages); err != nil {
+		return nil, err
+	}
+	return r.Pipeline(stages...)
+}
+
+func (p *Pipeline) Stages() []Stage {
+	return append([]Stage(nil), p.stages...)
+}
+
+func (p *Pipeline) MarshalJSON() ([]byte, error) {
+	if p.stages == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(p.stages)
+}
+
+// Apply runs every stage but a final reduce against c.
+func (p *Pipeline) Apply(c *Collection) *Collection {
+	for n, s := range p.stages {
+		switch s.Op {
+		case "map":
+			c = c.Map(p.fs[n].(function.F))
+		case "parallel_map":
+			c = c.ParallelMap(s.Parallelism, p.fs[n].(function.F))
+		case "select":
+			c = c.Select(p.fs[n].(predicate.P))
+		case "reject":
+			c = c.Reject(p.fs[n].(predicate.P))
+		}
+	}
+	return c
+}
+
+// Run applies p to c and returns the reduced value if the last stage is a
+// reduce, or else the resulting elements as a []interface{}.
+func (p *Pipeline) Run(c *Collection) (interface{}, error) {
+	c = p.Apply(c)
+	if n := len(p.stages) - 1; n >= 0 && p.stages[n].Op == "reduce" {
+		return c.Reduce(p.fs[n].(bifunction.B))
+	}
+	return c.Interfaces()
+}
@@ -0,0 +1,174 @@
+package fu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/samwho/fu/function"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc        string
+		spec        string
+		in          *Collection
+		out         interface{}
+		expectedErr bool
+	}{
+		{
+			desc: "map and reduce",
+			spec: `[{"map": "add", "args": [1]}, {"reduce": "sum"}]`,
+			in:   Ints(ctx, []int{1, 2, 3}),
+			out:  9,
+		},
+		{
+			desc: "select and reject",
+			spec: `[{"select": "gt", "args": [1]}, {"reject": "eq", "args": [3]}, {"map": "mul", "args": [10]}]`,
+			in:   Ints(ctx, []int{1, 2, 3, 4}),
+			out:  []interface{}{20, 40},
+		},
+		{
+			desc: "parallel map",
+			spec: `[{"parallel_map": "upper", "parallelism": 2}, {"reduce": "join", "args": [","]}]`,
+			in:   Strings(ctx, []string{"a", "b", "c"}),
+			out:  "A,B,C",
+		},
+		{
+			desc: "field and expr",
+			spec: `[{"select": "expr", "args": ["Age > 30"]}, {"map": "field", "args": ["Name"]}]`,
+			in:   people(),
+			out:  []interface{}{"Ann", "Cat", "Ann", "Dan"},
+		},
+		{
+			desc: "float argument",
+			spec: `[{"map": "to_float64"}, {"map": "mul", "args": [1.5]}]`,
+			in:   Ints(ctx, []int{2}),
+			out:  []interface{}{3.0},
+		},
+		{
+			desc: "empty",
+			spec: `[]`,
+			in:   Ints(ctx, []int{1}),
+			out:  []interface{}{1},
+		},
+		{
+			desc:        "run time error",
+			spec:        `[{"map": "div", "args": [0]}]`,
+			in:          Ints(ctx, []int{1}),
+			expectedErr: true,
+		},
+	}
+
+	r := NewRegistry()
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			p, err := r.ParsePipeline([]byte(tc.spec))
+			require.NoError(t, err)
+			out, err := p.Run(tc.in)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.out, out)
+		})
+	}
+}
+
+func TestPipelineValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc  string
+		spec  string
+		stage int
+	}{
+		{desc: "unknown function", spec: `[{"map": "add", "args": [1]}, {"map": "nope"}]`, stage: 1},
+		{desc: "wrong kind", spec: `[{"select": "add", "args": [1]}]`},
+		{desc: "reduce not last", spec: `[{"reduce": "sum"}, {"map": "neg"}]`},
+		{desc: "missing args", spec: `[{"map": "add"}]`},
+		{desc: "too many args", spec: `[{"map": "neg", "args": [1]}]`},
+		{desc: "bad arg type", spec: `[{"map": "field", "args": [1]}]`},
+		{desc: "constructor error", spec: `[{"select": "expr", "args": ["Age >"]}]`},
+		{desc: "no parallelism", spec: `[{"parallel_map": "neg"}]`},
+		{desc: "parallelism on map", spec: `[{"map": "neg", "parallelism": 2}]`},
+	}
+
+	r := NewRegistry()
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			_, err := r.ParsePipeline([]byte(tc.spec))
+			var pe *PipelineError
+			require.True(t, errors.As(err, &pe), "%v", err)
+			assert.Equal(t, tc.stage, pe.Stage)
+		})
+	}
+
+	for _, spec := range []string{
+		`{"map": "neg"}`,
+		`[{"map": "neg", "select": "gt"}]`,
+		`[{"filter": "gt"}]`,
+		`[{"args": [1]}]`,
+	} {
+		_, err := r.ParsePipeline([]byte(spec))
+		assert.Error(t, err, spec)
+	}
+	assert.True(t, errors.Is(func() error { _, err := r.Lookup("nope"); return err }(), ErrUnknownFunction))
+}
+
+func TestPipelineRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	spec := `[{"select":"gt","args":[1]},{"map":"string"},{"parallel_map":"pad_left","args":[4,48],"parallelism":2},{"reduce":"join","args":["-"]}]`
+	r := NewRegistry()
+	p, err := r.ParsePipeline([]byte(spec))
+	require.NoError(t, err)
+	bs, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.JSONEq(t, spec, string(bs))
+
+	p, err = r.ParsePipeline(bs)
+	require.NoError(t, err)
+	out, err := p.Run(Ints(ctx, []int{1, 22, 3}))
+	require.NoError(t, err)
+	assert.Equal(t, "0022-0003", out)
+}
+
+func TestRegistryRegister(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	require.NoError(t, r.Register("double", func(ctx context.Context, i interface{}) (interface{}, error) {
+		return i.(int) * 2, nil
+	}))
+	require.NoError(t, r.Register("even", func(ctx context.Context, i interface{}) (bool, error) {
+		return i.(int)%2 == 0, nil
+	}))
+	require.NoError(t, r.Register("add_both", func(a int, b int) function.F { return Add(a + b) }))
+
+	p, err := r.Pipeline(
+		Stage{Op: "select", Func: "even"},
+		Stage{Op: "map", Func: "double"},
+		Stage{Op: "map", Func: "add_both", Args: []interface{}{1, 2}},
+	)
+	require.NoError(t, err)
+	out, err := p.Run(Ints(ctx, []int{1, 2, 3, 4}))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{7, 11}, out)
+	assert.Contains(t, r.Names(), "double")
+
+	assert.Error(t, r.Register("bad", 42))
+	assert.Error(t, r.Register("bad", func() int { return 1 }))
+	assert.Error(t, r.Register("bad", nil))
+}
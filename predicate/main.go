@@ -2,6 +2,9 @@ package predicate
 
 import (
 	"context"
+	"reflect"
+
+	"github.com/samwho/fu/internal/lift"
 )
 
 type P interface {
@@ -21,3 +24,23 @@ func (p *predicateImpl) Test(ctx context.Context, i interface{}) (bool, error) {
 func New(f Fn) P {
 	return &predicateImpl{f: f}
 }
+
+// Lift adapts an ordinary func returning bool into a P. The func may take a
+// leading context.Context and may return a trailing error.
+func Lift(fn interface{}) (P, error) {
+	l, err := lift.New(fn, 1, reflect.Bool)
+	if err != nil {
+		return nil, err
+	}
+	return New(func(ctx context.Context, i interface{}) (bool, error) {
+		return l.CallBool(ctx, i)
+	}), nil
+}
+
+func MustLift(fn interface{}) P {
+	p, err := Lift(fn)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
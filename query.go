@@ -0,0 +1,369 @@
+package fu
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/expr"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/internal/hashkey"
+)
+
+// Query runs a SQL-like statement, such as
+//
+//	SELECT Name, COUNT(*) FROM _ WHERE Age > 30 GROUP BY Name
+//	ORDER BY COUNT(*) DESC LIMIT 10
+//
+// against the elements of c, which may be structs or maps, and returns a
+// Collection of map[string]interface{} rows keyed by column name. The FROM
+// name is ignored. Expressions use the syntax of Expr. The aggregates COUNT,
+// SUM, AVG, MIN and MAX may be used as whole columns, and ORDER BY may refer
+// to columns by name or alias.
+func Query(ctx context.Context, c *Collection, sql string) *Collection {
+	is, err := c.Interfaces()
+	if err == nil {
+		is, err = runQuery(ctx, is, sql)
+	}
+	return &Collection{ctx, is, err}
+}
+
+func (c *Collection) Query(sql string) *Collection {
+	return Query(c.ctx, c, sql)
+}
+
+type queryColumn struct {
+	expr.Column
+	f   function.F
+	agg string
+}
+
+type queryRow struct {
+	row  map[string]interface{}
+	src  interface{}
+	keys []interface{}
+}
+
+var aggregates = map[string]bool{"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true}
+
+func runQuery(ctx context.Context, is []interface{}, sql string) ([]interface{}, error) {
+	q, err := expr.ParseQuery(sql)
+	if err != nil {
+		return nil, err
+	}
+	c := &exprCompiler{src: sql}
+
+	cols := make([]*queryColumn, 0, len(q.Columns))
+	grouped := len(q.GroupBy) > 0
+	for _, col := range q.Columns {
+		qc := &queryColumn{Column: col}
+		if call, ok := col.Expr.(*expr.Call); ok && aggregates[strings.ToUpper(call.Name)] {
+			if qc.agg, qc.f, err = c.aggregate(call); err != nil {
+				return nil, err
+			}
+			grouped = true
+		} else if col.Expr != nil {
+			e, err := c.compile(col.Expr)
+			if err != nil {
+				return nil, err
+			}
+			qc.f = e.f
+		}
+		cols = append(cols, qc)
+	}
+
+	if q.Where != nil {
+		w, err := c.compile(q.Where)
+		if err != nil {
+			return nil, err
+		}
+		if is, err = Select(ctx, is, c.predicate(q.Where, w.f)); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []*queryRow
+	if grouped {
+		rows, err = c.groupRows(ctx, is, q, cols)
+	} else {
+		rows, err = projectRows(ctx, is, cols)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(q.OrderBy) > 0 {
+		if rows, err = c.orderRows(ctx, rows, q.OrderBy, cols, grouped); err != nil {
+			return nil, err
+		}
+	}
+
+	ret := make([]interface{}, 0, len(rows))
+	for _, r := range rows {
+		ret = append(ret, r.row)
+	}
+	if ret, err = Drop(ctx, ret, q.Offset); err != nil {
+		return nil, err
+	}
+	if q.Limit >= 0 {
+		return Take(ctx, ret, q.Limit)
+	}
+	return ret, nil
+}
+
+func (c *exprCompiler) aggregate(call *expr.Call) (string, function.F, error) {
+	name := strings.ToUpper(call.Name)
+	if call.Star {
+		if name != "COUNT" {
+			return "", nil, c.errorf(call, fmt.Errorf(`%s(*) is not supported`, name))
+		}
+		return name, nil, nil
+	}
+	if len(call.Args) != 1 {
+		return "", nil, c.errorf(call, fmt.Errorf(`%s takes one argument`, name))
+	}
+	e, err := c.compile(call.Args[0])
+	if err != nil {
+		return "", nil, err
+	}
+	return name, e.f, nil
+}
+
+func projectRows(ctx context.Context, is []interface{}, cols []*queryColumn) ([]*queryRow, error) {
+	rows := make([]*queryRow, 0, len(is))
+	for _, i := range is {
+		row := make(map[string]interface{}, len(cols))
+		for _, col := range cols {
+			if col.Expr == nil {
+				if err := allColumns(ctx, i, row); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			v, err := col.f.Call(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+			row[col.Name] = v
+		}
+		rows = append(rows, &queryRow{row: row, src: i})
+	}
+	return rows, nil
+}
+
+// allColumns adds every field of i, a struct or a map with string keys, to
+// row for SELECT *.
+func allColumns(ctx context.Context, i interface{}, row map[string]interface{}) error {
+	v := reflect.ValueOf(i)
+	if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+		iter := v.MapRange()
+		for iter.Next() {
+			row[iter.Key().String()] = iter.Value().Interface()
+		}
+		return nil
+	}
+	m, err := ToMap().Call(ctx, i)
+	if err != nil {
+		return err
+	}
+	for k, v := range m.(map[string]interface{}) {
+		row[k] = v
+	}
+	return nil
+}
+
+// groupRows produces one row per distinct GROUP BY key, in order of first
+// appearance, or a single row if there is no GROUP BY.
+func (c *exprCompiler) groupRows(ctx context.Context, is []interface{}, q *expr.Query, cols []*queryColumn) ([]*queryRow, error) {
+	keyFns := make([]function.F, 0, len(q.GroupBy))
+	for _, g := range q.GroupBy {
+		e, err := c.compile(g.Expr)
+		if err != nil {
+			return nil, err
+		}
+		keyFns = append(keyFns, e.f)
+	}
+	for _, col := range cols {
+		if col.agg != "" {
+			continue
+		}
+		if col.Expr == nil {
+			return nil, &expr.Error{Expr: c.src, Err: fmt.Errorf(`cannot SELECT * with GROUP BY or aggregates`)}
+		}
+		found := false
+		for _, g := range q.GroupBy {
+			found = found || expr.SameName(col.Text, g.Text)
+		}
+		if !found {
+			return nil, c.errorf(col.Expr, fmt.Errorf(`%s must appear in GROUP BY or be aggregated`, col.Text))
+		}
+	}
+
+	keyType := reflect.ArrayOf(len(keyFns), reflect.TypeOf((*interface{})(nil)).Elem())
+	index := make(map[interface{}]int)
+	var groups [][]interface{}
+	for _, i := range is {
+		key := reflect.New(keyType).Elem()
+		for n, f := range keyFns {
+			k, err := f.Call(ctx, i)
+			if err != nil {
+				return nil, err
+			}
+			if err := hashkey.Check(k); err != nil {
+				return nil, c.errorf(q.GroupBy[n].Expr, fmt.Errorf(`cannot group by: %w`, err))
+			}
+			if k != nil {
+				key.Index(n).Set(reflect.ValueOf(k))
+			}
+		}
+		g, ok := index[key.Interface()]
+		if !ok {
+			g = len(groups)
+			index[key.Interface()] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	if len(q.GroupBy) == 0 && len(groups) == 0 {
+		groups = append(groups, []interface{}{})
+	}
+
+	rows := make([]*queryRow, 0, len(groups))
+	for _, g := range groups {
+		row := make(map[string]interface{}, len(cols))
+		for _, col := range cols {
+			var v interface{}
+			var err error
+			if col.agg != "" {
+				v, err = aggregateGroup(ctx, col.agg, col.f, g)
+			} else {
+				v, err = col.f.Call(ctx, g[0])
+			}
+			if err != nil {
+				return nil, c.errorf(col.Expr, err)
+			}
+			row[col.Name] = v
+		}
+		rows = append(rows, &queryRow{row: row})
+	}
+	return rows, nil
+}
+
+// aggregateGroup ignores nil values, as SQL ignores NULLs, and returns nil
+// rather than an error for SUM, AVG, MIN and MAX of nothing.
+func aggregateGroup(ctx context.Context, agg string, f function.F, is []interface{}) (interface{}, error) {
+	if f == nil {
+		return len(is), nil
+	}
+	vs := make([]interface{}, 0, len(is))
+	for _, i := range is {
+		v, err := f.Call(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			vs = append(vs, v)
+		}
+	}
+	if agg == "COUNT" {
+		return len(vs), nil
+	}
+	if len(vs) == 0 {
+		return nil, nil
+	}
+	switch agg {
+	case "SUM":
+		return Reduce(ctx, vs, Sum())
+	case "AVG":
+		return Mean().Call(ctx, vs)
+	case "MIN":
+		return Min(ctx, vs, Compare())
+	default:
+		return Max(ctx, vs, Compare())
+	}
+}
+
+func compareNullsFirst(a interface{}, b interface{}) (int, error) {
+	switch {
+	case a == nil && b == nil:
+		return 0, nil
+	case a == nil:
+		return -1, nil
+	case b == nil:
+		return 1, nil
+	}
+	return compare(a, b)
+}
+
+// orderRows sorts by columns named in ORDER BY, or else by expressions
+// evaluated against the source element, or the row itself when grouped.
+func (c *exprCompiler) orderRows(ctx context.Context, rows []*queryRow, orders []expr.Order, cols []*queryColumn, grouped bool) ([]*queryRow, error) {
+	keyFns := make([]function.F, 0, len(orders))
+	named := make([]string, 0, len(orders))
+	for _, o := range orders {
+		name := ""
+		for _, col := range cols {
+			if col.Expr != nil && (expr.SameName(o.Name, col.Name) || expr.SameName(o.Name, col.Text)) {
+				name = col.Name
+			}
+		}
+		named = append(named, name)
+		if name != "" {
+			keyFns = append(keyFns, nil)
+			continue
+		}
+		e, err := c.compile(o.Expr)
+		if err != nil {
+			return nil, err
+		}
+		keyFns = append(keyFns, e.f)
+	}
+	for _, r := range rows {
+		for n, f := range keyFns {
+			if f == nil {
+				r.keys = append(r.keys, r.row[named[n]])
+				continue
+			}
+			src := r.src
+			if grouped {
+				src = r.row
+			}
+			k, err := f.Call(ctx, src)
+			if err != nil {
+				return nil, err
+			}
+			r.keys = append(r.keys, k)
+		}
+	}
+
+	is := make([]interface{}, 0, len(rows))
+	for _, r := range rows {
+		is = append(is, r)
+	}
+	sorted, err := Sort(ctx, is, bifunction.New(func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+		ak, bk := a.(*queryRow).keys, b.(*queryRow).keys
+		for n, o := range orders {
+			cmp, err := compareNullsFirst(ak[n], bk[n])
+			if err != nil {
+				return nil, c.errorf(o.Expr, err)
+			}
+			if o.Desc {
+				cmp = -cmp
+			}
+			if cmp != 0 {
+				return cmp, nil
+			}
+		}
+		return 0, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*queryRow, 0, len(sorted))
+	for _, r := range sorted {
+		ret = append(ret, r.(*queryRow))
+	}
+	return ret, nil
+}
@@ -0,0 +1,152 @@
+package fu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samwho/fu/expr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type queryPerson struct {
+	Name string
+	Age  int
+	City string
+}
+
+func people() *Collection {
+	return mixed(
+		queryPerson{"Ann", 42, "Leeds"},
+		queryPerson{"Bob", 25, "York"},
+		queryPerson{"Cat", 35, "Leeds"},
+		queryPerson{"Ann", 51, "Hull"},
+		queryPerson{"Dan", 31, "York"},
+	)
+}
+
+type row = map[string]interface{}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc        string
+		sql         string
+		out         []interface{}
+		expectedErr bool
+	}{
+		{
+			desc: "request example",
+			sql:  `SELECT Name, COUNT(*) FROM _ WHERE Age > 30 GROUP BY Name ORDER BY COUNT(*) DESC LIMIT 10`,
+			out: []interface{}{
+				row{"Name": "Ann", "COUNT(*)": 2},
+				row{"Name": "Cat", "COUNT(*)": 1},
+				row{"Name": "Dan", "COUNT(*)": 1},
+			},
+		},
+		{
+			desc: "projection",
+			sql:  `select Name, Age + 1 as next from people where City = 'York'`,
+			out:  []interface{}{row{"Name": "Bob", "next": 26}, row{"Name": "Dan", "next": 32}},
+		},
+		{
+			desc: "order by source field",
+			sql:  `SELECT Name FROM _ ORDER BY Age DESC LIMIT 2`,
+			out:  []interface{}{row{"Name": "Ann"}, row{"Name": "Ann"}},
+		},
+		{
+			desc: "order by several",
+			sql:  `SELECT Name, Age FROM _ WHERE Name <> "Bob" AND NOT Age > 45 ORDER BY City, Age DESC`,
+			out: []interface{}{
+				row{"Name": "Ann", "Age": 42},
+				row{"Name": "Cat", "Age": 35},
+				row{"Name": "Dan", "Age": 31},
+			},
+		},
+		{
+			desc: "sql keywords",
+			sql:  `select Name from _ where Name IN ["Ann", "Bob"] and City != NULL or Age = 35 order by Age`,
+			out:  []interface{}{row{"Name": "Bob"}, row{"Name": "Cat"}, row{"Name": "Ann"}, row{"Name": "Ann"}},
+		},
+		{
+			desc: "offset",
+			sql:  `SELECT Name FROM _ ORDER BY Name, Age LIMIT 2 OFFSET 1`,
+			out:  []interface{}{row{"Name": "Ann"}, row{"Name": "Bob"}},
+		},
+		{
+			desc: "aggregates",
+			sql:  `SELECT City, SUM(Age) AS total, AVG(Age), MIN(Name), MAX(Age), COUNT(Name) FROM _ GROUP BY City ORDER BY total`,
+			out: []interface{}{
+				row{"City": "Hull", "total": 51, "AVG(Age)": 51.0, "MIN(Name)": "Ann", "MAX(Age)": 51, "COUNT(Name)": 1},
+				row{"City": "York", "total": 56, "AVG(Age)": 28.0, "MIN(Name)": "Bob", "MAX(Age)": 31, "COUNT(Name)": 2},
+				row{"City": "Leeds", "total": 77, "AVG(Age)": 38.5, "MIN(Name)": "Ann", "MAX(Age)": 42, "COUNT(Name)": 2},
+			},
+		},
+		{
+			desc: "aggregate without group",
+			sql:  `SELECT COUNT(*) AS n, SUM(Age) FROM _ WHERE Age > 100`,
+			out:  []interface{}{row{"n": 0, "SUM(Age)": nil}},
+		},
+		{
+			desc: "order by aggregate alias in grouped query",
+			sql:  `SELECT City, count(*) AS n FROM _ GROUP BY City ORDER BY n DESC, City`,
+			out: []interface{}{
+				row{"City": "Leeds", "n": 2},
+				row{"City": "York", "n": 2},
+				row{"City": "Hull", "n": 1},
+			},
+		},
+		{
+			desc: "star",
+			sql:  `SELECT * FROM _ WHERE Name = "Cat"`,
+			out:  []interface{}{row{"Name": "Cat", "Age": 35, "City": "Leeds"}},
+		},
+		{desc: "ungrouped column", sql: `SELECT Name, COUNT(*) FROM _ GROUP BY City`, expectedErr: true},
+		{desc: "star with group", sql: `SELECT * FROM _ GROUP BY City`, expectedErr: true},
+		{desc: "nested aggregate", sql: `SELECT COUNT(*) + 1 FROM _`, expectedErr: true},
+		{desc: "sum star", sql: `SELECT SUM(*) FROM _`, expectedErr: true},
+		{desc: "missing from", sql: `SELECT Name WHERE Age > 1`, expectedErr: true},
+		{desc: "bad limit", sql: `SELECT Name FROM _ LIMIT ten`, expectedErr: true},
+		{desc: "trailing", sql: `SELECT Name FROM _ LIMIT 1 garbage`, expectedErr: true},
+		{desc: "runtime", sql: `SELECT Name FROM _ WHERE Missing > 1`, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := Query(ctx, people(), tC.sql).Interfaces()
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestQueryMaps(t *testing.T) {
+	rows, err := mixed(
+		map[string]interface{}{"sku": "a", "qty": 2},
+		map[string]interface{}{"sku": "b", "qty": 5},
+		map[string]interface{}{"sku": "a", "qty": 1},
+	).Query(`SELECT sku, SUM(qty) AS qty FROM orders GROUP BY sku ORDER BY qty DESC`).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{row{"sku": "b", "qty": 5}, row{"sku": "a", "qty": 3}}, rows)
+}
+
+func TestQueryGroupByNestedSlice(t *testing.T) {
+	_, err := mixed(
+		map[string]interface{}{"k": [1]interface{}{[]int{1}}},
+	).Query(`SELECT k FROM _ GROUP BY k`).Interfaces()
+	assert.Error(t, err)
+}
+
+func TestQueryErrorPosition(t *testing.T) {
+	_, err := Query(ctx, people(), `SELECT Name FROM _ WHERE Age >`).Interfaces()
+	var ee *expr.Error
+	require.True(t, errors.As(err, &ee))
+	assert.Equal(t, 30, ee.Pos)
+}
@@ -0,0 +1,232 @@
+package fu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/internal/lift"
+	"github.com/samwho/fu/predicate"
+)
+
+var (
+	functionType   = reflect.TypeOf((*function.F)(nil)).Elem()
+	predicateType  = reflect.TypeOf((*predicate.P)(nil)).Elem()
+	bifunctionType = reflect.TypeOf((*bifunction.B)(nil)).Elem()
+)
+
+var ErrUnknownFunction = errors.New("unknown function")
+
+// Registry maps names to functions so that pipelines can be described as
+// data. Entries are either ready-made values, such as a function.F or a
+// function.Fn, or constructors such as Add or Field whose arguments are
+// supplied when a pipeline is built. Constructors may also return an error.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[string]reflect.Value
+}
+
+// NewRegistry returns a Registry containing the built-in functions under
+// snake_case names, such as "add", "gt", "field", "join" and "expr".
+func NewRegistry() *Registry {
+	r := &Registry{entries: make(map[string]reflect.Value)}
+	for name, fn := range builtins {
+		if err := r.Register(name, fn); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+var builtins = map[string]interface{}{
+	"identity":      Identity,
+	"string":        String,
+	"len":           Len,
+	"field":         Field,
+	"path":          Path,
+	"pluck":         Pluck,
+	"expr":          Expr,
+	"add":           Add,
+	"sub":           Sub,
+	"mul":           Mul,
+	"div":           Div,
+	"mod":           Mod,
+	"pow":           Pow,
+	"neg":           Neg,
+	"abs":           Abs,
+	"sum":           Sum,
+	"negative_sum":  NegativeSum,
+	"multiply":      Multiply,
+	"quotient":      Quotient,
+	"remainder":     Remainder,
+	"join":          Join,
+	"gt":            Gt,
+	"lt":            Lt,
+	"gte":           Gte,
+	"lte":           Lte,
+	"eq":            Eq,
+	"neq":           Neq,
+	"between":       Between,
+	"has_prefix":    HasPrefix,
+	"has_suffix":    HasSuffix,
+	"contains":      Contains,
+	"equal_fold":    EqualFold,
+	"matches":       Matches,
+	"is_empty":      IsEmpty,
+	"len_between":   LenBetween,
+	"upper":         Upper,
+	"lower":         Lower,
+	"trim":          Trim,
+	"split":         Split,
+	"replace":       Replace,
+	"regex_replace": RegexReplace,
+	"substring":     Substring,
+	"pad":           Pad,
+	"pad_left":      PadLeft,
+	"truncate":      Truncate,
+	"format":        Format,
+	"to_int":        ToInt,
+	"to_int64":      ToInt64,
+	"to_float64":    ToFloat64,
+	"to_string":     ToString,
+	"to_bool":       ToBool,
+	"parse_time":    ParseTime,
+}
+
+func implementsAny(t reflect.Type) bool {
+	return t.Implements(functionType) || t.Implements(predicateType) || t.Implements(bifunctionType)
+}
+
+// Register adds fn under name, replacing any existing entry.
+func (r *Registry) Register(name string, fn interface{}) error {
+	if fn == nil {
+		return fmt.Errorf(`cannot register nil as %q`, name)
+	}
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	fnType, pnType, bnType := reflect.TypeOf(function.Fn(nil)), reflect.TypeOf(predicate.Fn(nil)), reflect.TypeOf(bifunction.Fn(nil))
+	switch {
+	case implementsAny(t):
+	case t.ConvertibleTo(fnType):
+		v = reflect.ValueOf(function.New(v.Convert(fnType).Interface().(function.Fn)))
+	case t.ConvertibleTo(pnType):
+		v = reflect.ValueOf(predicate.New(v.Convert(pnType).Interface().(predicate.Fn)))
+	case t.ConvertibleTo(bnType):
+		v = reflect.ValueOf(bifunction.New(v.Convert(bnType).Interface().(bifunction.Fn)))
+	case t.Kind() == reflect.Func:
+		if t.NumOut() == 0 || t.NumOut() > 2 || !implementsAny(t.Out(0)) || t.NumOut() == 2 && t.Out(1) != errorType {
+			return fmt.Errorf(`cannot register %q: %v must return a function.F, predicate.P or bifunction.B and optionally an error`, name, t)
+		}
+	default:
+		return fmt.Errorf(`cannot register %q: unsupported type %v`, name, t)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[name] = v
+	return nil
+}
+
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.entries))
+	for n := range r.entries {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the entry called name, calling it with args if it is a
+// constructor. Arguments are converted to the constructor's parameter types
+// where that loses nothing.
+func (r *Registry) Lookup(name string, args ...interface{}) (interface{}, error) {
+	r.mu.RLock()
+	v, ok := r.entries[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf(`%w: %q`, ErrUnknownFunction, name)
+	}
+	if v.Kind() != reflect.Func || implementsAny(v.Type()) {
+		if len(args) > 0 {
+			return nil, fmt.Errorf(`%q takes no arguments, got %d`, name, len(args))
+		}
+		return v.Interface(), nil
+	}
+
+	t := v.Type()
+	if n := t.NumIn(); len(args) != n && !(t.IsVariadic() && len(args) >= n-1) {
+		return nil, fmt.Errorf(`%q takes %d arguments, got %d`, name, n, len(args))
+	}
+	in := make([]reflect.Value, 0, len(args))
+	for n, a := range args {
+		pt := paramType(t, n)
+		av, err := convertArg(a, pt)
+		if err != nil {
+			return nil, fmt.Errorf(`%q argument %d: %w`, name, n, err)
+		}
+		in = append(in, av)
+	}
+	out := v.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, fmt.Errorf(`%q: %w`, name, out[1].Interface().(error))
+	}
+	return out[0].Interface(), nil
+}
+
+func paramType(t reflect.Type, n int) reflect.Type {
+	if t.IsVariadic() && n >= t.NumIn()-1 {
+		return t.In(t.NumIn() - 1).Elem()
+	}
+	return t.In(n)
+}
+
+// convertArg converts a decoded JSON value to t. Numbers that arrive as
+// json.Number become ints when whole and float64s otherwise, and arrays are
+// converted element by element.
+func convertArg(a interface{}, t reflect.Type) (reflect.Value, error) {
+	a = normaliseJSON(a)
+	if is, ok := a.([]interface{}); ok && t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Interface {
+		s := reflect.MakeSlice(t, 0, len(is))
+		for _, i := range is {
+			v, err := convertArg(i, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			s = reflect.Append(s, v)
+		}
+		return s, nil
+	}
+	return lift.Convert(a, t)
+}
+
+func normaliseJSON(a interface{}) interface{} {
+	switch v := a.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil && int64(int(n)) == n {
+			return int(n)
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case []interface{}:
+		ret := make([]interface{}, 0, len(v))
+		for _, i := range v {
+			ret = append(ret, normaliseJSON(i))
+		}
+		return ret
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, i := range v {
+			ret[k] = normaliseJSON(i)
+		}
+		return ret
+	}
+	return a
+}
@@ -0,0 +1,252 @@
+package fu
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DecodeError reports the line of input that a source failed to read.
+type DecodeError struct {
+	Line int
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf(`line %d: %v`, e.Line, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// FromSlice returns a Collection of the elements of any slice or array.
+func FromSlice(ctx context.Context, s interface{}) *Collection {
+	if s == nil {
+		return &Collection{ctx, []interface{}{}, nil}
+	}
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return &Collection{ctx, nil, fmt.Errorf(`cannot make collection from non-slice: %T`, s)}
+	}
+	is := make([]interface{}, 0, v.Len())
+	for n := 0; n < v.Len(); n++ {
+		is = append(is, v.Index(n).Interface())
+	}
+	return &Collection{ctx, is, nil}
+}
+
+// FromMap returns a Collection of a Pair for each key and value in the
+// map m, in key order if the keys can be compared and in map order
+// otherwise. To convert a map to a struct, see ToStruct.
+func FromMap(ctx context.Context, m interface{}) *Collection {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return &Collection{ctx, nil, fmt.Errorf(`cannot make collection from non-map: %T`, m)}
+	}
+	keys := v.MapKeys()
+	var cmpErr error
+	sorted := append([]reflect.Value(nil), keys...)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		c, err := compare(sorted[a].Interface(), sorted[b].Interface())
+		if err != nil {
+			cmpErr = err
+		}
+		return c < 0
+	})
+	if cmpErr == nil {
+		keys = sorted
+	}
+	is := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		is = append(is, Pair{k.Interface(), v.MapIndex(k).Interface()})
+	}
+	return &Collection{ctx, is, nil}
+}
+
+// source makes a Stream from a func that calls yield for each element and
+// returns any error, stopping if the context is done.
+func source(ctx context.Context, each func(yield func(interface{}) bool) error) *Stream {
+	return &Stream{ctx, func(yield func(interface{}, error) bool) {
+		stopped := false
+		err := each(func(i interface{}) bool {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				stopped = true
+				return false
+			}
+			if !yield(i, nil) {
+				stopped = true
+				return false
+			}
+			return true
+		})
+		if err != nil && !stopped {
+			yield(nil, err)
+		}
+	}}
+}
+
+// FromChan returns a Stream of the values received from ch until it is
+// closed or the context is done.
+func FromChan[T any](ctx context.Context, ch <-chan T) *Stream {
+	return &Stream{ctx, func(yield func(interface{}, error) bool) {
+		for {
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			case t, ok := <-ch:
+				if !ok || !yield(t, nil) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// FromSeq returns a Stream of the values of seq, stopping early if the
+// context is done.
+func FromSeq[T any](ctx context.Context, seq iter.Seq[T]) *Stream {
+	return source(ctx, func(yield func(interface{}) bool) error {
+		for t := range seq {
+			if !yield(t) {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+// FromSeq2 returns a Stream of a Pair for each key and value in seq.
+func FromSeq2[K any, V any](ctx context.Context, seq iter.Seq2[K, V]) *Stream {
+	return source(ctx, func(yield func(interface{}) bool) error {
+		for k, v := range seq {
+			if !yield(Pair{k, v}) {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+// FromLines returns a Stream of the lines of r as strings, without their
+// line endings.
+func FromLines(ctx context.Context, r io.Reader) *Stream {
+	return source(ctx, func(yield func(interface{}) bool) error {
+		br := bufio.NewReader(r)
+		for line := 1; ; line++ {
+			s, err := br.ReadString('\n')
+			if err != nil && err != io.EOF {
+				return &DecodeError{line, err}
+			}
+			if s == "" && err == io.EOF {
+				return nil
+			}
+			if !yield(strings.TrimSuffix(strings.TrimSuffix(s, "\n"), "\r")) {
+				return nil
+			}
+			if err == io.EOF {
+				return nil
+			}
+		}
+	})
+}
+
+// FromCSV returns a Stream of a map[string]interface{} for each record of r
+// after the header, keyed by the header. Values are strings, and may be
+// converted to structs with ToStruct.
+func FromCSV(ctx context.Context, r io.Reader) *Stream {
+	return source(ctx, func(yield func(interface{}) bool) error {
+		cr := csv.NewReader(r)
+		header, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return csvError(err)
+		}
+		header = append([]string(nil), header...)
+		for {
+			rec, err := cr.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return csvError(err)
+			}
+			m := make(map[string]interface{}, len(header))
+			for n, h := range header {
+				m[h] = rec[n]
+			}
+			if !yield(m) {
+				return nil
+			}
+		}
+	})
+}
+
+func csvError(err error) error {
+	var pe *csv.ParseError
+	if errors.As(err, &pe) {
+		return &DecodeError{pe.Line, pe.Err}
+	}
+	return err
+}
+
+// FromJSONLines returns a Stream of the JSON values in r, one per line,
+// decoded into values of type t. Blank lines are skipped. If t is nil, values
+// decode as they would into an interface{}, except that whole numbers become
+// ints rather than float64s.
+func FromJSONLines(ctx context.Context, r io.Reader, t reflect.Type) *Stream {
+	return source(ctx, func(yield func(interface{}) bool) error {
+		br := bufio.NewReader(r)
+		for line := 1; ; line++ {
+			bs, err := br.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				return &DecodeError{line, err}
+			}
+			if s := strings.TrimSpace(string(bs)); s != "" {
+				i, derr := decodeJSON(s, t)
+				if derr != nil {
+					return &DecodeError{line, derr}
+				}
+				if !yield(i) {
+					return nil
+				}
+			}
+			if err == io.EOF {
+				return nil
+			}
+		}
+	})
+}
+
+func decodeJSON(s string, t reflect.Type) (interface{}, error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	var v reflect.Value
+	if t == nil {
+		d.UseNumber()
+		v = reflect.New(reflect.TypeOf((*interface{})(nil)).Elem())
+	} else {
+		v = reflect.New(t)
+	}
+	if err := d.Decode(v.Interface()); err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	if t == nil {
+		return normaliseJSON(v.Elem().Interface()), nil
+	}
+	return v.Elem().Interface(), nil
+}
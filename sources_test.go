@@ -0,0 +1,168 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromSlice(t *testing.T) {
+	t.Parallel()
+
+	is, err := FromSlice(ctx, []stringUser{{"Ann", 42}, {"Bob", 25}}).Map(Field("Name")).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"Ann", "Bob"}, is)
+
+	is, err = FromSlice(ctx, [2]float32{1.5, 2}).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{float32(1.5), float32(2)}, is)
+
+	is, err = FromSlice(ctx, nil).Interfaces()
+	require.NoError(t, err)
+	assert.Empty(t, is)
+
+	_, err = FromSlice(ctx, 42).Interfaces()
+	assert.Error(t, err)
+}
+
+func TestFromEntries(t *testing.T) {
+	t.Parallel()
+
+	is, err := FromMap(ctx, map[string]int{"b": 2, "a": 1, "c": 3}).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{Pair{"a", 1}, Pair{"b", 2}, Pair{"c", 3}}, is)
+
+	is, err = FromMap(ctx, map[interface{}]bool{1: true, "x": false}).Interfaces()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []interface{}{Pair{1, true}, Pair{"x", false}}, is)
+
+	_, err = FromMap(ctx, []int{1}).Interfaces()
+	assert.Error(t, err)
+}
+
+func TestFromChan(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	is, err := FromChan(ctx, ch).Map(Add(1)).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 3, 4}, is)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = FromChan(cctx, make(chan int)).Interfaces()
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestFromSeq(t *testing.T) {
+	t.Parallel()
+
+	is, err := FromSeq(ctx, slices.Values([]string{"a", "b", "c"})).Take(2).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, is)
+
+	is, err = FromSeq2(ctx, maps.All(map[string]int{"a": 1})).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{Pair{"a", 1}}, is)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = FromSeq(cctx, slices.Values([]int{1})).Interfaces()
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestFromLines(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		in   string
+		out  []interface{}
+	}{
+		{desc: "trailing newline", in: "a\nb\n", out: []interface{}{"a", "b"}},
+		{desc: "no trailing newline", in: "a\n\nb", out: []interface{}{"a", "", "b"}},
+		{desc: "crlf", in: "a\r\nb\r\n", out: []interface{}{"a", "b"}},
+		{desc: "empty", in: ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			is, err := FromLines(ctx, strings.NewReader(tc.in)).Interfaces()
+			require.NoError(t, err)
+			assert.Equal(t, tc.out, is)
+		})
+	}
+}
+
+func TestFromCSV(t *testing.T) {
+	t.Parallel()
+
+	in := "Name,Age\nAnn,42\n\"Bob, Jr\",25\n"
+	is, err := FromCSV(ctx, strings.NewReader(in)).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"Name": "Ann", "Age": "42"},
+		map[string]interface{}{"Name": "Bob, Jr", "Age": "25"},
+	}, is)
+
+	is, err = FromCSV(ctx, strings.NewReader("")).Interfaces()
+	require.NoError(t, err)
+	assert.Empty(t, is)
+
+	_, err = FromCSV(ctx, strings.NewReader("a,b\n1,2\n3\n")).Interfaces()
+	var de *DecodeError
+	require.True(t, errors.As(err, &de), "%v", err)
+	assert.Equal(t, 3, de.Line)
+}
+
+func TestFromJSONLines(t *testing.T) {
+	t.Parallel()
+
+	in := `{"Name": "Ann", "Age": 42}
+
+{"Name": "Bob", "Age": 25.5}
+`
+	is, err := FromJSONLines(ctx, strings.NewReader(in), nil).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"Name": "Ann", "Age": 42},
+		map[string]interface{}{"Name": "Bob", "Age": 25.5},
+	}, is)
+
+	is, err = FromJSONLines(ctx, strings.NewReader(`{"Name": "Ann", "Age": 42}`), reflect.TypeOf(&stringUser{})).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{&stringUser{"Ann", 42}}, is)
+
+	testCases := []struct {
+		desc string
+		in   string
+		line int
+	}{
+		{desc: "syntax", in: "{}\n\n{\n", line: 3},
+		{desc: "trailing data", in: "1 2\n", line: 1},
+		{desc: "type", in: "{}\n{\"Age\": \"x\"}", line: 2},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			_, err := FromJSONLines(ctx, strings.NewReader(tc.in), reflect.TypeOf(stringUser{})).Interfaces()
+			var de *DecodeError
+			require.True(t, errors.As(err, &de), "%v", err)
+			assert.Equal(t, tc.line, de.Line)
+		})
+	}
+}
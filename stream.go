@@ -0,0 +1,300 @@
+package fu
+
+import (
+	"context"
+	"iter"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/mapper"
+	"github.com/samwho/fu/predicate"
+)
+
+// Stream is a lazily evaluated pipeline. Nothing is computed until a
+// terminal method such as Interfaces, Reduce or First is called, and
+// upstream functions are only called for as many elements as are needed.
+type Stream struct {
+	ctx context.Context
+	seq iter.Seq2[interface{}, error]
+}
+
+func Lazy(ctx context.Context, is []interface{}) *Stream {
+	return &Stream{ctx, func(yield func(interface{}, error) bool) {
+		for _, i := range is {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}}
+}
+
+func (c *Collection) Lazy() *Stream {
+	if c.err != nil {
+		err := c.err
+		return &Stream{c.ctx, func(yield func(interface{}, error) bool) {
+			yield(nil, err)
+		}}
+	}
+	return Lazy(c.ctx, c.is)
+}
+
+func (s *Stream) Seq() iter.Seq2[interface{}, error] {
+	return s.seq
+}
+
+func (s *Stream) MapFn(f function.Fn) *Stream {
+	return s.Map(function.New(f))
+}
+
+func (s *Stream) Map(f function.F) *Stream {
+	prev := s.seq
+	s.seq = func(yield func(interface{}, error) bool) {
+		for i, err := range prev {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			r, err := f.Call(s.ctx, i)
+			if !yield(r, err) || err != nil {
+				return
+			}
+		}
+	}
+	return s
+}
+
+func (s *Stream) FlatMapFn(f function.Fn) *Stream {
+	return s.FlatMap(function.New(f))
+}
+
+func (s *Stream) FlatMap(f function.F) *Stream {
+	prev := s.seq
+	s.seq = func(yield func(interface{}, error) bool) {
+		for i, err := range prev {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			r, err := f.Call(s.ctx, i)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			rs, err := mapper.Expand(s.ctx, r)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, r := range rs {
+				if !yield(r, nil) {
+					return
+				}
+			}
+		}
+	}
+	return s
+}
+
+func (s *Stream) SelectFn(p predicate.Fn) *Stream {
+	return s.Select(predicate.New(p))
+}
+
+func (s *Stream) Select(p predicate.P) *Stream {
+	prev := s.seq
+	s.seq = func(yield func(interface{}, error) bool) {
+		for i, err := range prev {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			b, err := p.Test(s.ctx, i)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if b && !yield(i, nil) {
+				return
+			}
+		}
+	}
+	return s
+}
+
+func (s *Stream) RejectFn(p predicate.Fn) *Stream {
+	return s.Reject(predicate.New(p))
+}
+
+func (s *Stream) Reject(p predicate.P) *Stream {
+	return s.Select(Not(p))
+}
+
+func (s *Stream) Take(n int) *Stream {
+	prev := s.seq
+	s.seq = func(yield func(interface{}, error) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for i, err := range prev {
+			if !yield(i, err) || err != nil {
+				return
+			}
+			taken++
+			if taken >= n {
+				return
+			}
+		}
+	}
+	return s
+}
+
+func (s *Stream) Drop(n int) *Stream {
+	prev := s.seq
+	s.seq = func(yield func(interface{}, error) bool) {
+		dropped := 0
+		for i, err := range prev {
+			if err == nil && dropped < n {
+				dropped++
+				continue
+			}
+			if !yield(i, err) || err != nil {
+				return
+			}
+		}
+	}
+	return s
+}
+
+func (s *Stream) TakeWhileFn(p predicate.Fn) *Stream {
+	return s.TakeWhile(predicate.New(p))
+}
+
+func (s *Stream) TakeWhile(p predicate.P) *Stream {
+	prev := s.seq
+	s.seq = func(yield func(interface{}, error) bool) {
+		for i, err := range prev {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			b, err := p.Test(s.ctx, i)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !b || !yield(i, nil) {
+				return
+			}
+		}
+	}
+	return s
+}
+
+func (s *Stream) DropWhileFn(p predicate.Fn) *Stream {
+	return s.DropWhile(predicate.New(p))
+}
+
+func (s *Stream) DropWhile(p predicate.P) *Stream {
+	prev := s.seq
+	s.seq = func(yield func(interface{}, error) bool) {
+		dropping := true
+		for i, err := range prev {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if dropping {
+				b, err := p.Test(s.ctx, i)
+				if err != nil {
+					yield(nil, err)
+					return
+				}
+				if b {
+					continue
+				}
+				dropping = false
+			}
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+	return s
+}
+
+func (s *Stream) First(p predicate.P) (interface{}, bool, error) {
+	for i, err := range s.seq {
+		if err != nil {
+			return nil, false, err
+		}
+		b, err := p.Test(s.ctx, i)
+		if err != nil {
+			return nil, false, err
+		}
+		if b {
+			return i, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (s *Stream) FindIndex(p predicate.P) (int, error) {
+	idx := 0
+	for i, err := range s.seq {
+		if err != nil {
+			return -1, err
+		}
+		b, err := p.Test(s.ctx, i)
+		if err != nil {
+			return -1, err
+		}
+		if b {
+			return idx, nil
+		}
+		idx++
+	}
+	return -1, nil
+}
+
+func (s *Stream) Interfaces() ([]interface{}, error) {
+	var ret []interface{}
+	for i, err := range s.seq {
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, i)
+	}
+	return ret, nil
+}
+
+func (s *Stream) Collect() *Collection {
+	is, err := s.Interfaces()
+	return &Collection{s.ctx, is, err}
+}
+
+func (s *Stream) ReduceFn(bf bifunction.Fn) (interface{}, error) {
+	return s.Reduce(bifunction.New(bf))
+}
+
+func (s *Stream) Reduce(bf bifunction.B) (interface{}, error) {
+	var ret interface{}
+	first := true
+	for i, err := range s.seq {
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			ret, first = i, false
+			continue
+		}
+		ret, err = bf.Call(s.ctx, ret, i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,114 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/samwho/fu/function"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func counting(calls *int64, f function.F) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		atomic.AddInt64(calls, 1)
+		return f.Call(ctx, i)
+	})
+}
+
+func TestStreamTakeStopsEarly(t *testing.T) {
+	var calls int64
+	result, err := Ints(ctx, []int{1, 2, 3, 4, 5}).Lazy().Map(counting(&calls, Mul(10))).Take(2).Collect().Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 20}, result)
+	assert.Equal(t, int64(2), calls)
+}
+
+func TestStreamTakeWhileStopsEarly(t *testing.T) {
+	var calls int64
+	result, err := Ints(ctx, []int{1, 2, 3, 4, 5}).Lazy().Map(counting(&calls, Mul(10))).TakeWhile(Lt(30)).Collect().Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{10, 20}, result)
+	assert.Equal(t, int64(3), calls)
+}
+
+func TestStreamFirstStopsEarly(t *testing.T) {
+	var calls int64
+	i, ok, err := Ints(ctx, []int{1, 2, 3, 4, 5}).Lazy().Map(counting(&calls, Mul(10))).First(Gt(15))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 20, i)
+	assert.Equal(t, int64(2), calls)
+}
+
+func TestStreamFirstLeavesStream(t *testing.T) {
+	s := Lazy(ctx, []interface{}{1, 2, 3, 4})
+	i, ok, err := s.First(Gt(2))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, i)
+
+	result, err := s.Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3, 4}, result)
+}
+
+func TestStreamFindIndex(t *testing.T) {
+	var calls int64
+	idx, err := Lazy(ctx, []interface{}{1, 2, 3, 4}).Map(counting(&calls, Identity())).FindIndex(Eq(2))
+	require.NoError(t, err)
+	assert.Equal(t, 1, idx)
+	assert.Equal(t, int64(2), calls)
+
+	idx, err = Lazy(ctx, []interface{}{1, 2}).FindIndex(Eq(3))
+	require.NoError(t, err)
+	assert.Equal(t, -1, idx)
+}
+
+func TestStreamDrop(t *testing.T) {
+	result, err := Lazy(ctx, []interface{}{1, 2, 3, 4}).Drop(1).DropWhile(Lt(3)).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{3, 4}, result)
+}
+
+func TestStreamSelectReject(t *testing.T) {
+	result, err := Lazy(ctx, []interface{}{1, 2, 3, 4}).Select(Gt(1)).Reject(Eq(3)).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 4}, result)
+}
+
+func TestStreamFlatMap(t *testing.T) {
+	result, err := Lazy(ctx, []interface{}{"a b", "c"}).FlatMap(words).Take(2).Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, result)
+}
+
+func TestStreamReduce(t *testing.T) {
+	result, err := Lazy(ctx, []interface{}{1, 2, 3}).Reduce(Sum())
+	require.NoError(t, err)
+	assert.Equal(t, 6, result)
+
+	result, err = Lazy(ctx, nil).Reduce(Sum())
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestStreamError(t *testing.T) {
+	_, err := Lazy(ctx, []interface{}{1, 2}).MapFn(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return nil, errors.New("")
+	}).Take(1).Interfaces()
+	assert.Error(t, err)
+
+	_, err = Strings(ctx, []string{"a"}).Map(Add(1)).Lazy().Interfaces()
+	assert.Error(t, err)
+}
+
+func TestStreamCancelled(t *testing.T) {
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := Lazy(cctx, []interface{}{1}).Interfaces()
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package fu
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+)
+
+var ErrNotString = errors.New("not a string")
+
+type NotStringError struct {
+	Func  string
+	Value interface{}
+}
+
+func (e *NotStringError) Error() string {
+	return fmt.Sprintf(`%s: %v: %T %v`, e.Func, ErrNotString, e.Value, e.Value)
+}
+
+func (e *NotStringError) Is(target error) bool {
+	return target == ErrNotString
+}
+
+// asString accepts strings and named string types.
+func asString(fn string, i interface{}) (string, error) {
+	if s, ok := i.(string); ok {
+		return s, nil
+	}
+	if i != nil {
+		if v := reflect.ValueOf(i); v.Kind() == reflect.String {
+			return v.String(), nil
+		}
+	}
+	return "", &NotStringError{fn, i}
+}
+
+func stringP(fn string, f func(string) bool) predicate.P {
+	return predicate.New(func(ctx context.Context, i interface{}) (bool, error) {
+		s, err := asString(fn, i)
+		if err != nil {
+			return false, err
+		}
+		return f(s), nil
+	})
+}
+
+func stringF(fn string, f func(string) interface{}) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		s, err := asString(fn, i)
+		if err != nil {
+			return nil, err
+		}
+		return f(s), nil
+	})
+}
+
+func HasPrefix(prefix string) predicate.P {
+	return stringP("HasPrefix", func(s string) bool { return strings.HasPrefix(s, prefix) })
+}
+
+func HasSuffix(suffix string) predicate.P {
+	return stringP("HasSuffix", func(s string) bool { return strings.HasSuffix(s, suffix) })
+}
+
+func Contains(substr string) predicate.P {
+	return stringP("Contains", func(s string) bool { return strings.Contains(s, substr) })
+}
+
+func EqualFold(t string) predicate.P {
+	return stringP("EqualFold", func(s string) bool { return strings.EqualFold(s, t) })
+}
+
+func Matches(pattern string) predicate.P {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return predicate.New(func(ctx context.Context, i interface{}) (bool, error) {
+			return false, err
+		})
+	}
+	return stringP("Matches", re.MatchString)
+}
+
+func IsEmpty() predicate.P {
+	return stringP("IsEmpty", func(s string) bool { return s == "" })
+}
+
+// LenBetween counts runes rather than bytes, and is inclusive at both ends.
+func LenBetween(min int, max int) predicate.P {
+	return stringP("LenBetween", func(s string) bool {
+		n := utf8.RuneCountInString(s)
+		return n >= min && n <= max
+	})
+}
+
+func Upper() function.F {
+	return stringF("Upper", func(s string) interface{} { return strings.ToUpper(s) })
+}
+
+func Lower() function.F {
+	return stringF("Lower", func(s string) interface{} { return strings.ToLower(s) })
+}
+
+func Trim() function.F {
+	return stringF("Trim", func(s string) interface{} { return strings.TrimSpace(s) })
+}
+
+func Split(sep string) function.F {
+	return stringF("Split", func(s string) interface{} {
+		parts := strings.Split(s, sep)
+		is := make([]interface{}, 0, len(parts))
+		for _, p := range parts {
+			is = append(is, p)
+		}
+		return is
+	})
+}
+
+func Replace(old string, new string) function.F {
+	return stringF("Replace", func(s string) interface{} { return strings.ReplaceAll(s, old, new) })
+}
+
+// RegexReplace replaces every match of pattern, expanding $1 and ${name} in
+// repl as regexp.ReplaceAllString does.
+func RegexReplace(pattern string, repl string) function.F {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+	return stringF("RegexReplace", func(s string) interface{} { return re.ReplaceAllString(s, repl) })
+}
+
+// Substring returns the runes in [start, end), clamping both to the bounds of
+// the string.
+func Substring(start int, end int) function.F {
+	return stringF("Substring", func(s string) interface{} {
+		rs := []rune(s)
+		start, end := clamp(start, len(rs)), clamp(end, len(rs))
+		if start >= end {
+			return ""
+		}
+		return string(rs[start:end])
+	})
+}
+
+func clamp(i int, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+// Pad appends fill until the string is width runes long. Strings that are
+// already long enough are returned unchanged.
+func Pad(width int, fill rune) function.F {
+	return stringF("Pad", func(s string) interface{} {
+		if n := width - utf8.RuneCountInString(s); n > 0 {
+			return s + strings.Repeat(string(fill), n)
+		}
+		return s
+	})
+}
+
+func PadLeft(width int, fill rune) function.F {
+	return stringF("PadLeft", func(s string) interface{} {
+		if n := width - utf8.RuneCountInString(s); n > 0 {
+			return strings.Repeat(string(fill), n) + s
+		}
+		return s
+	})
+}
+
+// Truncate cuts strings down to at most n runes.
+func Truncate(n int) function.F {
+	return stringF("Truncate", func(s string) interface{} {
+		rs := []rune(s)
+		return string(rs[:clamp(n, len(rs))])
+	})
+}
+
+// Format executes a text/template with each element as dot, so
+// `{{.Name}} is {{.Age}}` works on structs and maps alike. Unlike the other
+// string functions it accepts input of any type.
+func Format(tmpl string) function.F {
+	t, err := template.New("Format").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, i); err != nil {
+			return nil, err
+		}
+		return buf.String(), nil
+	})
+}
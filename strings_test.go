@@ -0,0 +1,125 @@
+package fu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stringName string
+
+type stringUser struct {
+	Name string
+	Age  int
+}
+
+func TestStringPredicates(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc        string
+		p           predicate.P
+		in          interface{}
+		out         bool
+		expectedErr bool
+	}{
+		{desc: "has prefix", p: HasPrefix("foo"), in: "foobar", out: true},
+		{desc: "has prefix false", p: HasPrefix("bar"), in: "foobar", out: false},
+		{desc: "has suffix", p: HasSuffix("bar"), in: "foobar", out: true},
+		{desc: "contains", p: Contains("oba"), in: "foobar", out: true},
+		{desc: "contains false", p: Contains("baz"), in: "foobar", out: false},
+		{desc: "equal fold", p: EqualFold("FooBar"), in: "fOObAR", out: true},
+		{desc: "matches", p: Matches(`^\d+$`), in: "123", out: true},
+		{desc: "matches false", p: Matches(`^\d+$`), in: "12a", out: false},
+		{desc: "matches bad pattern", p: Matches(`(`), in: "x", expectedErr: true},
+		{desc: "is empty", p: IsEmpty(), in: "", out: true},
+		{desc: "is empty false", p: IsEmpty(), in: " ", out: false},
+		{desc: "len between", p: LenBetween(2, 3), in: "héé", out: true},
+		{desc: "len between short", p: LenBetween(2, 3), in: "h", out: false},
+		{desc: "named string", p: HasPrefix("sam"), in: stringName("samwho"), out: true},
+		{desc: "not a string", p: HasPrefix("1"), in: 123, expectedErr: true},
+		{desc: "nil", p: IsEmpty(), in: nil, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.p.Test(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestStringFunctions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc        string
+		f           function.F
+		in          interface{}
+		out         interface{}
+		expectedErr bool
+	}{
+		{desc: "upper", f: Upper(), in: "héllo", out: "HÉLLO"},
+		{desc: "lower", f: Lower(), in: "HeLLo", out: "hello"},
+		{desc: "trim", f: Trim(), in: "\t hello \n", out: "hello"},
+		{desc: "split", f: Split(","), in: "a,b,,c", out: []interface{}{"a", "b", "", "c"}},
+		{desc: "replace", f: Replace("o", "0"), in: "foo", out: "f00"},
+		{desc: "regex replace", f: RegexReplace(`(\w+)@(\w+)`, "$2 at $1"), in: "sam@example", out: "example at sam"},
+		{desc: "regex replace bad pattern", f: RegexReplace(`[`, ""), in: "x", expectedErr: true},
+		{desc: "substring", f: Substring(1, 3), in: "héllo", out: "él"},
+		{desc: "substring clamped", f: Substring(-5, 50), in: "hello", out: "hello"},
+		{desc: "substring empty", f: Substring(3, 1), in: "hello", out: ""},
+		{desc: "pad", f: Pad(5, '.'), in: "ab", out: "ab..."},
+		{desc: "pad long enough", f: Pad(2, '.'), in: "abc", out: "abc"},
+		{desc: "pad left", f: PadLeft(4, '0'), in: "42", out: "0042"},
+		{desc: "truncate", f: Truncate(3), in: "héllo", out: "hél"},
+		{desc: "truncate short", f: Truncate(10), in: "hi", out: "hi"},
+		{desc: "truncate negative", f: Truncate(-1), in: "hi", out: ""},
+		{desc: "format struct", f: Format("{{.Name}} is {{.Age}}"), in: stringUser{Name: "Sam", Age: 30}, out: "Sam is 30"},
+		{desc: "format map", f: Format(`{{index . "k"}}!`), in: map[string]string{"k": "v"}, out: "v!"},
+		{desc: "format missing key", f: Format("{{.missing}}"), in: map[string]string{}, expectedErr: true},
+		{desc: "format bad template", f: Format("{{"), in: "x", expectedErr: true},
+		{desc: "upper not string", f: Upper(), in: 1.5, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.f.Call(ctx, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestStringCollections(t *testing.T) {
+	result, err := Strings(ctx, []string{" Apple", "banana ", " avocado "}).
+		Map(Trim()).
+		Select(HasPrefix("a")).
+		Map(Upper()).
+		Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AVOCADO"}, result)
+
+	_, err = Ints(ctx, []int{1}).Map(Upper()).Strings()
+	assert.True(t, errors.Is(err, ErrNotString))
+	var nse *NotStringError
+	require.True(t, errors.As(err, &nse))
+	assert.Equal(t, "Upper", nse.Func)
+	assert.Equal(t, 1, nse.Value)
+}
@@ -0,0 +1,124 @@
+package fu
+
+import (
+	"context"
+
+	"github.com/samwho/fu/predicate"
+)
+
+func Take(ctx context.Context, is []interface{}, n int) ([]interface{}, error) {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(is) {
+		n = len(is)
+	}
+	return is[:n:n], nil
+}
+
+func Drop(ctx context.Context, is []interface{}, n int) ([]interface{}, error) {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(is) {
+		n = len(is)
+	}
+	return is[n:], nil
+}
+
+func TakeWhile(ctx context.Context, is []interface{}, p predicate.P) ([]interface{}, error) {
+	idx, err := FindIndex(ctx, is, Not(p))
+	if err != nil {
+		return nil, err
+	}
+	if idx < 0 {
+		return is, nil
+	}
+	return is[:idx:idx], nil
+}
+
+func DropWhile(ctx context.Context, is []interface{}, p predicate.P) ([]interface{}, error) {
+	idx, err := FindIndex(ctx, is, Not(p))
+	if err != nil {
+		return nil, err
+	}
+	if idx < 0 {
+		return []interface{}{}, nil
+	}
+	return is[idx:], nil
+}
+
+func First(ctx context.Context, is []interface{}, p predicate.P) (interface{}, bool, error) {
+	idx, err := FindIndex(ctx, is, p)
+	if err != nil || idx < 0 {
+		return nil, false, err
+	}
+	return is[idx], true, nil
+}
+
+func FindIndex(ctx context.Context, is []interface{}, p predicate.P) (int, error) {
+	for idx, i := range is {
+		b, err := p.Test(ctx, i)
+		if err != nil {
+			return -1, err
+		}
+		if b {
+			return idx, nil
+		}
+	}
+	return -1, nil
+}
+
+func (c *Collection) Take(n int) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = Take(c.ctx, c.is, n)
+	return c
+}
+
+func (c *Collection) Drop(n int) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = Drop(c.ctx, c.is, n)
+	return c
+}
+
+func (c *Collection) TakeWhileFn(p predicate.Fn) *Collection {
+	return c.TakeWhile(predicate.New(p))
+}
+
+func (c *Collection) TakeWhile(p predicate.P) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = TakeWhile(c.ctx, c.is, p)
+	return c
+}
+
+func (c *Collection) DropWhileFn(p predicate.Fn) *Collection {
+	return c.DropWhile(predicate.New(p))
+}
+
+func (c *Collection) DropWhile(p predicate.P) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = DropWhile(c.ctx, c.is, p)
+	return c
+}
+
+func (c *Collection) First(p predicate.P) (interface{}, bool, error) {
+	if c.err != nil {
+		return nil, false, c.err
+	}
+	return First(c.ctx, c.is, p)
+}
+
+func (c *Collection) FindIndex(p predicate.P) (int, error) {
+	if c.err != nil {
+		return -1, c.err
+	}
+	return FindIndex(c.ctx, c.is, p)
+}
@@ -0,0 +1,90 @@
+package fu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixes(t *testing.T) {
+	t.Parallel()
+
+	in := []interface{}{1, 2, 3, 1}
+	testCases := []struct {
+		desc string
+		f    func() ([]interface{}, error)
+		out  []interface{}
+	}{
+		{desc: "take", f: func() ([]interface{}, error) { return Take(ctx, in, 2) }, out: []interface{}{1, 2}},
+		{desc: "take all", f: func() ([]interface{}, error) { return Take(ctx, in, 10) }, out: in},
+		{desc: "take negative", f: func() ([]interface{}, error) { return Take(ctx, in, -1) }, out: []interface{}{}},
+		{desc: "drop", f: func() ([]interface{}, error) { return Drop(ctx, in, 3) }, out: []interface{}{1}},
+		{desc: "drop all", f: func() ([]interface{}, error) { return Drop(ctx, in, 10) }, out: []interface{}{}},
+		{desc: "take while", f: func() ([]interface{}, error) { return TakeWhile(ctx, in, Lt(3)) }, out: []interface{}{1, 2}},
+		{desc: "take while all", f: func() ([]interface{}, error) { return TakeWhile(ctx, in, Lt(4)) }, out: in},
+		{desc: "drop while", f: func() ([]interface{}, error) { return DropWhile(ctx, in, Lt(3)) }, out: []interface{}{3, 1}},
+		{desc: "drop while all", f: func() ([]interface{}, error) { return DropWhile(ctx, in, Lt(4)) }, out: []interface{}{}},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.f()
+			require.NoError(t, err)
+			assert.Equal(t, tC.out, res)
+		})
+	}
+}
+
+func TestFirst(t *testing.T) {
+	i, ok, err := First(ctx, []interface{}{1, 2, 3}, Gt(1))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, i)
+
+	_, ok, err = First(ctx, []interface{}{1, 2, 3}, Gt(3))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = First(ctx, []interface{}{"a"}, Gt(3))
+	assert.Error(t, err)
+}
+
+func TestFindIndex(t *testing.T) {
+	idx, err := FindIndex(ctx, []interface{}{1, 2, 3}, Eq(3))
+	require.NoError(t, err)
+	assert.Equal(t, 2, idx)
+
+	idx, err = FindIndex(ctx, []interface{}{1, 2, 3}, Eq(4))
+	require.NoError(t, err)
+	assert.Equal(t, -1, idx)
+}
+
+func TestCollectionTake(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3, 4}).Drop(1).Take(2).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, result)
+}
+
+func TestCollectionTakeWhile(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3, 4}).DropWhile(Lt(2)).TakeWhile(Lt(4)).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, result)
+}
+
+func TestCollectionFirst(t *testing.T) {
+	i, ok, err := Ints(ctx, []int{1, 2, 3, 4}).First(Gt(2))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, i)
+
+	_, _, err = Strings(ctx, []string{"a"}).Map(Add(1)).First(Gt(2))
+	assert.Error(t, err)
+}
+
+func TestCollectionFindIndex(t *testing.T) {
+	idx, err := Ints(ctx, []int{1, 2, 3, 4}).FindIndex(Gt(2))
+	require.NoError(t, err)
+	assert.Equal(t, 2, idx)
+}
@@ -0,0 +1,168 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+)
+
+var ErrNotTime = errors.New("not a time")
+
+type Clock interface {
+	Now() time.Time
+}
+
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// FixedClock always returns t, which makes time-relative predicates such as
+// OlderThan deterministic in tests.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+type clockKey struct{}
+
+// WithClock returns a context that time-relative functions read the current
+// time from. Without one they use time.Now.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+func ClockFrom(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return c
+	}
+	return ClockFunc(time.Now)
+}
+
+func asTime(i interface{}) (time.Time, error) {
+	switch t := i.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t != nil {
+			return *t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(`%w: %T %v`, ErrNotTime, i, i)
+}
+
+func timeP(f func(ctx context.Context, t time.Time) bool) predicate.P {
+	return predicate.New(func(ctx context.Context, i interface{}) (bool, error) {
+		t, err := asTime(i)
+		if err != nil {
+			return false, err
+		}
+		return f(ctx, t), nil
+	})
+}
+
+func timeF(f func(t time.Time) time.Time) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		t, err := asTime(i)
+		if err != nil {
+			return nil, err
+		}
+		return f(t), nil
+	})
+}
+
+func Before(t time.Time) predicate.P {
+	return timeP(func(ctx context.Context, u time.Time) bool { return u.Before(t) })
+}
+
+func After(t time.Time) predicate.P {
+	return timeP(func(ctx context.Context, u time.Time) bool { return u.After(t) })
+}
+
+// Within is true for times in the half-open interval [start, end).
+func Within(start time.Time, end time.Time) predicate.P {
+	return timeP(func(ctx context.Context, u time.Time) bool { return !u.Before(start) && u.Before(end) })
+}
+
+// OlderThan is true for times more than d before the context's clock.
+func OlderThan(d time.Duration) predicate.P {
+	return timeP(func(ctx context.Context, u time.Time) bool { return ClockFrom(ctx).Now().Sub(u) > d })
+}
+
+// TruncateTime rounds down to a multiple of d since the zero time, as
+// time.Time.Truncate does. Use Day, Week and Month for calendar boundaries.
+func TruncateTime(d time.Duration) function.F {
+	return timeF(func(t time.Time) time.Time { return t.Truncate(d) })
+}
+
+// Day returns midnight at the start of the day in loc, which makes a good
+// GroupBy key. A nil loc means UTC, here and in Week and Month.
+func Day(loc *time.Location) function.F {
+	loc = orUTC(loc)
+	return timeF(func(t time.Time) time.Time {
+		t = t.In(loc)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	})
+}
+
+// Week returns midnight on the Monday that starts the ISO week in loc.
+func Week(loc *time.Location) function.F {
+	loc = orUTC(loc)
+	return timeF(func(t time.Time) time.Time {
+		t = t.In(loc)
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc)
+	})
+}
+
+// Month returns midnight on the first of the month in loc.
+func Month(loc *time.Location) function.F {
+	loc = orUTC(loc)
+	return timeF(func(t time.Time) time.Time {
+		t = t.In(loc)
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+	})
+}
+
+func orUTC(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// DurationBetween returns b minus a, so it is positive when b is later.
+func DurationBetween() bifunction.B {
+	return bifunction.New(func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+		at, err := asTime(a)
+		if err != nil {
+			return nil, err
+		}
+		bt, err := asTime(b)
+		if err != nil {
+			return nil, err
+		}
+		return bt.Sub(at), nil
+	})
+}
+
+// DurationBetweenBy applies kf to both arguments first, so
+// DurationBetweenBy(Path("At")) measures the gap between two events.
+func DurationBetweenBy(kf function.F) bifunction.B {
+	return bifunction.New(func(ctx context.Context, a interface{}, b interface{}) (interface{}, error) {
+		ak, err := kf.Call(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		bk, err := kf.Call(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		return DurationBetween().Call(ctx, ak, bk)
+	})
+}
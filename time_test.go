@@ -0,0 +1,129 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/predicate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type event struct {
+	Name string
+	At   time.Time
+}
+
+var epoch = time.Date(2021, 3, 10, 22, 30, 0, 0, time.UTC)
+
+func TestTimePredicates(t *testing.T) {
+	t.Parallel()
+
+	clocked := WithClock(ctx, FixedClock(epoch))
+	testCases := []struct {
+		desc        string
+		p           predicate.P
+		in          interface{}
+		out         bool
+		expectedErr bool
+	}{
+		{desc: "before", p: Before(epoch), in: epoch.Add(-time.Second), out: true},
+		{desc: "before equal", p: Before(epoch), in: epoch, out: false},
+		{desc: "after", p: After(epoch), in: epoch.Add(time.Second), out: true},
+		{desc: "after pointer", p: After(epoch), in: &epoch, out: false},
+		{desc: "within start", p: Within(epoch, epoch.Add(time.Hour)), in: epoch, out: true},
+		{desc: "within end", p: Within(epoch, epoch.Add(time.Hour)), in: epoch.Add(time.Hour), out: false},
+		{desc: "older than", p: OlderThan(time.Hour), in: epoch.Add(-2 * time.Hour), out: true},
+		{desc: "not older than", p: OlderThan(time.Hour), in: epoch.Add(-30 * time.Minute), out: false},
+		{desc: "field path", p: Where(Path("At"), Before(epoch)), in: event{"a", epoch.Add(-time.Minute)}, out: true},
+		{desc: "not a time", p: Before(epoch), in: "yesterday", expectedErr: true},
+		{desc: "bad path", p: Where(Path("When"), Before(epoch)), in: event{}, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.p.Test(clocked, tC.in)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestDefaultClock(t *testing.T) {
+	b, err := OlderThan(time.Hour).Test(context.Background(), time.Now().Add(-2*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, b)
+}
+
+func TestTimeFunctions(t *testing.T) {
+	t.Parallel()
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	testCases := []struct {
+		desc        string
+		f           function.F
+		in          interface{}
+		out         time.Time
+		expectedErr bool
+	}{
+		{desc: "truncate", f: TruncateTime(time.Hour), in: epoch.Add(15 * time.Minute), out: time.Date(2021, 3, 10, 22, 0, 0, 0, time.UTC)},
+		{desc: "day", f: Day(time.UTC), in: epoch, out: time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{desc: "day in location", f: Day(tokyo), in: epoch, out: time.Date(2021, 3, 11, 0, 0, 0, 0, tokyo)},
+		{desc: "week", f: Week(time.UTC), in: epoch, out: time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{desc: "week on sunday", f: Week(time.UTC), in: time.Date(2021, 3, 14, 12, 0, 0, 0, time.UTC), out: time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{desc: "week across month", f: Week(time.UTC), in: time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC), out: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{desc: "month", f: Month(time.UTC), in: epoch, out: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{desc: "field path", f: function.Compose(Path("At"), Month(time.UTC)), in: event{"a", epoch}, out: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{desc: "day nil location", f: Day(nil), in: epoch, out: time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{desc: "week nil location", f: Week(nil), in: epoch, out: time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{desc: "month nil location", f: Month(nil), in: epoch, out: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{desc: "not a time", f: Day(time.UTC), in: 1, expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := tC.f.Call(ctx, tC.in)
+			if tC.expectedErr {
+				assert.True(t, errors.Is(err, ErrNotTime))
+			} else {
+				require.NoError(t, err)
+				assert.True(t, tC.out.Equal(res.(time.Time)), "%v != %v", tC.out, res)
+			}
+		})
+	}
+}
+
+func TestGroupByDay(t *testing.T) {
+	es := []interface{}{
+		event{"a", epoch},
+		event{"b", epoch.Add(time.Hour)},
+		event{"c", epoch.Add(3 * time.Hour)},
+	}
+	m, err := GroupBy(ctx, function.Compose(Path("At"), Day(time.UTC)), es)
+	require.NoError(t, err)
+	assert.Len(t, m, 2)
+	assert.Len(t, m[time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)], 2)
+}
+
+func TestDurationBetween(t *testing.T) {
+	d, err := DurationBetween().Call(ctx, epoch, epoch.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, d)
+
+	d, err = DurationBetweenBy(Path("At")).Call(ctx, event{"b", epoch.Add(time.Hour)}, event{"a", epoch})
+	require.NoError(t, err)
+	assert.Equal(t, -time.Hour, d)
+
+	_, err = DurationBetween().Call(ctx, epoch, "later")
+	assert.True(t, errors.Is(err, ErrNotTime))
+}
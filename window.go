@@ -0,0 +1,34 @@
+package fu
+
+import (
+	"context"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/window"
+)
+
+func Windows(ctx context.Context, is []interface{}, w *window.W) ([]interface{}, error) {
+	return w.Windows(ctx, is)
+}
+
+func (c *Collection) Window(w *window.W) *Collection {
+	if c.err != nil {
+		return c
+	}
+	c.is, c.err = Windows(c.ctx, c.is, w)
+	return c
+}
+
+func (s *Stream) Window(w *window.W) *Stream {
+	s.seq = w.Apply(s.ctx, s.seq)
+	return s
+}
+
+// ProcessingTime is a timestamp function for windowing elements by when they
+// are seen rather than by a time they carry. It reads the clock from the
+// context, so WithClock can be used to fake it.
+func ProcessingTime() function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return ClockFrom(ctx).Now(), nil
+	})
+}
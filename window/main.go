@@ -0,0 +1,304 @@
+package window
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"time"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/reducer"
+)
+
+// Window is emitted once for every window that closes. Value is the result of
+// the reduction or aggregation, or the window's elements as a []interface{}
+// if neither was given.
+type Window struct {
+	Start time.Time
+	End   time.Time
+	Count int
+	Value interface{}
+}
+
+type kind int
+
+const (
+	tumbling kind = iota
+	sliding
+	session
+)
+
+// W assigns timestamped elements to windows. Elements may arrive out of
+// order: the watermark trails the latest timestamp seen by the allowed
+// lateness, and a window closes once the watermark passes its end. Elements
+// that only belong to closed windows are late and are dropped, or passed to
+// the OnLate handler.
+type W struct {
+	kind     kind
+	ts       function.F
+	size     time.Duration
+	slide    time.Duration
+	bf       bifunction.B
+	agg      function.F
+	lateness time.Duration
+	onLate   function.F
+}
+
+// Tumbling windows are adjacent and never overlap. They are aligned to the
+// zero time, as time.Time.Truncate is.
+func Tumbling(ts function.F, size time.Duration) *W {
+	return &W{kind: tumbling, ts: ts, size: size, slide: size}
+}
+
+// Sliding windows are size long and start every slide, so each element
+// belongs to about size/slide windows. If slide is longer than size, elements
+// that fall between windows belong to none and are dropped without being
+// passed to OnLate.
+func Sliding(ts function.F, size time.Duration, slide time.Duration) *W {
+	return &W{kind: sliding, ts: ts, size: size, slide: slide}
+}
+
+// Session windows grow for as long as elements arrive less than gap apart.
+func Session(ts function.F, gap time.Duration) *W {
+	return &W{kind: session, ts: ts, size: gap}
+}
+
+// Reduce folds the elements of each window, in timestamp order, with bf.
+func (w *W) Reduce(bf bifunction.B) *W {
+	cp := *w
+	cp.bf, cp.agg = bf, nil
+	return &cp
+}
+
+// Aggregate calls f with the []interface{} of each window's elements, in
+// timestamp order, so aggregations such as fu.Mean can be used.
+func (w *W) Aggregate(f function.F) *W {
+	cp := *w
+	cp.bf, cp.agg = nil, f
+	return &cp
+}
+
+func (w *W) AllowedLateness(d time.Duration) *W {
+	cp := *w
+	cp.lateness = d
+	return &cp
+}
+
+// OnLate calls f with every late element. An error from f stops the stream.
+func (w *W) OnLate(f function.F) *W {
+	cp := *w
+	cp.onLate = f
+	return &cp
+}
+
+func (w *W) validate() error {
+	if w.ts == nil {
+		return errors.New("window: no timestamp function")
+	}
+	if w.size <= 0 || w.slide < 0 || w.kind == sliding && w.slide <= 0 {
+		return fmt.Errorf(`window: durations must be positive: size %v, slide %v`, w.size, w.slide)
+	}
+	if w.lateness < 0 {
+		return fmt.Errorf(`window: negative lateness: %v`, w.lateness)
+	}
+	return nil
+}
+
+type element struct {
+	t time.Time
+	i interface{}
+}
+
+type pane struct {
+	start time.Time
+	end   time.Time
+	es    []element
+}
+
+type state struct {
+	w         *W
+	ctx       context.Context
+	panes     []*pane
+	watermark time.Time
+	started   bool
+}
+
+func (s *state) timestamp(i interface{}) (time.Time, error) {
+	r, err := s.w.ts.Call(s.ctx, i)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, ok := r.(time.Time)
+	if !ok {
+		return time.Time{}, fmt.Errorf(`window: timestamp is not a time.Time: %T %v`, r, r)
+	}
+	return t, nil
+}
+
+func (s *state) open(end time.Time) bool {
+	return !s.started || end.After(s.watermark)
+}
+
+// add assigns e to its windows and reports whether it was late, meaning that
+// it belonged to windows but all of them had closed.
+func (s *state) add(e element) bool {
+	switch s.w.kind {
+	case session:
+		end := e.t.Add(s.w.size)
+		if !s.open(end) {
+			return true
+		}
+		merged := &pane{start: e.t, end: end, es: []element{e}}
+		kept := s.panes[:0]
+		for _, p := range s.panes {
+			if p.start.Before(merged.end) && merged.start.Before(p.end) {
+				if p.start.Before(merged.start) {
+					merged.start = p.start
+				}
+				if p.end.After(merged.end) {
+					merged.end = p.end
+				}
+				merged.es = append(merged.es, p.es...)
+				continue
+			}
+			kept = append(kept, p)
+		}
+		s.panes = append(kept, merged)
+		return false
+	default:
+		belongs, added := false, false
+		last := e.t.Truncate(s.w.slide)
+		for start := last; start.Add(s.w.size).After(e.t); start = start.Add(-s.w.slide) {
+			belongs = true
+			end := start.Add(s.w.size)
+			if !s.open(end) {
+				break
+			}
+			p := s.pane(start, end)
+			p.es = append(p.es, e)
+			added = true
+		}
+		return belongs && !added
+	}
+}
+
+func (s *state) pane(start time.Time, end time.Time) *pane {
+	for _, p := range s.panes {
+		if p.start.Equal(start) {
+			return p
+		}
+	}
+	p := &pane{start: start, end: end}
+	s.panes = append(s.panes, p)
+	return p
+}
+
+// closed removes and returns, in order, the panes the watermark has passed.
+// All panes are closed once the input is exhausted.
+func (s *state) closed(all bool) []*pane {
+	var ret []*pane
+	kept := s.panes[:0]
+	for _, p := range s.panes {
+		if all || !p.end.After(s.watermark) {
+			ret = append(ret, p)
+		} else {
+			kept = append(kept, p)
+		}
+	}
+	s.panes = kept
+	sort.SliceStable(ret, func(a, b int) bool {
+		if !ret[a].end.Equal(ret[b].end) {
+			return ret[a].end.Before(ret[b].end)
+		}
+		return ret[a].start.Before(ret[b].start)
+	})
+	return ret
+}
+
+func (s *state) emit(p *pane) (Window, error) {
+	sort.SliceStable(p.es, func(a, b int) bool { return p.es[a].t.Before(p.es[b].t) })
+	is := make([]interface{}, 0, len(p.es))
+	for _, e := range p.es {
+		is = append(is, e.i)
+	}
+	win := Window{Start: p.start, End: p.end, Count: len(is), Value: is}
+	var err error
+	switch {
+	case s.w.bf != nil:
+		win.Value, err = reducer.New(s.w.bf).Reduce(s.ctx, is)
+	case s.w.agg != nil:
+		win.Value, err = s.w.agg.Call(s.ctx, is)
+	}
+	return win, err
+}
+
+// Apply windows a sequence of elements, yielding a Window for each window
+// as it closes and flushing the rest once seq is exhausted.
+func (w *W) Apply(ctx context.Context, seq iter.Seq2[interface{}, error]) iter.Seq2[interface{}, error] {
+	return func(yield func(interface{}, error) bool) {
+		if err := w.validate(); err != nil {
+			yield(nil, err)
+			return
+		}
+		s := &state{w: w, ctx: ctx}
+		flush := func(all bool) bool {
+			for _, p := range s.closed(all) {
+				win, err := s.emit(p)
+				if err != nil {
+					yield(nil, err)
+					return false
+				}
+				if !yield(win, nil) {
+					return false
+				}
+			}
+			return true
+		}
+		for i, err := range seq {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			t, err := s.timestamp(i)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if s.add(element{t, i}) && w.onLate != nil {
+				if _, err := w.onLate.Call(ctx, i); err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+			if wm := t.Add(-w.lateness); !s.started || wm.After(s.watermark) {
+				s.watermark, s.started = wm, true
+			}
+			if !flush(false) {
+				return
+			}
+		}
+		flush(true)
+	}
+}
+
+// Windows applies w to a slice, returning a []interface{} of Window values.
+func (w *W) Windows(ctx context.Context, is []interface{}) ([]interface{}, error) {
+	ret := make([]interface{}, 0)
+	seq := func(yield func(interface{}, error) bool) {
+		for _, i := range is {
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+	for win, err := range w.Apply(ctx, seq) {
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, win)
+	}
+	return ret, nil
+}
@@ -0,0 +1,204 @@
+package fu
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/window"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reading struct {
+	At    time.Time
+	Value int
+}
+
+func at(seconds int, value int) reading {
+	return reading{epoch.Add(time.Duration(seconds) * time.Second), value}
+}
+
+func readings(rs ...reading) []interface{} {
+	is := make([]interface{}, 0, len(rs))
+	for _, r := range rs {
+		is = append(is, r)
+	}
+	return is
+}
+
+// win builds the expected Window for [start, end) seconds after epoch.
+func win(start int, end int, count int, value interface{}) window.Window {
+	return window.Window{
+		Start: epoch.Add(time.Duration(start) * time.Second),
+		End:   epoch.Add(time.Duration(end) * time.Second),
+		Count: count,
+		Value: value,
+	}
+}
+
+var values = function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+	return Map(ctx, i.([]interface{}), Field("Value"))
+})
+
+func TestWindows(t *testing.T) {
+	t.Parallel()
+
+	ts := Path("At")
+	testCases := []struct {
+		desc        string
+		w           *window.W
+		in          []interface{}
+		out         []interface{}
+		expectedErr bool
+	}{
+		{
+			desc: "tumbling",
+			w:    window.Tumbling(ts, 10*time.Second).Aggregate(values),
+			in:   readings(at(1, 1), at(5, 2), at(12, 3), at(31, 4)),
+			out: []interface{}{
+				win(0, 10, 2, []interface{}{1, 2}),
+				win(10, 20, 1, []interface{}{3}),
+				win(30, 40, 1, []interface{}{4}),
+			},
+		},
+		{
+			desc: "tumbling reduced",
+			w:    window.Tumbling(ts, 10*time.Second).Aggregate(function.Compose(values, ReduceEach(Sum()))),
+			in:   readings(at(1, 1), at(5, 2), at(12, 3)),
+			out:  []interface{}{win(0, 10, 2, 3), win(10, 20, 1, 3)},
+		},
+		{
+			desc: "tumbling mean",
+			w:    window.Tumbling(ts, time.Minute).Aggregate(function.Compose(values, Mean())),
+			in:   readings(at(1, 1), at(5, 2)),
+			out:  []interface{}{win(0, 60, 2, 1.5)},
+		},
+		{
+			desc: "sliding",
+			w:    window.Sliding(ts, 10*time.Second, 5*time.Second).Aggregate(values),
+			in:   readings(at(1, 1), at(7, 2), at(12, 3)),
+			out: []interface{}{
+				win(-5, 5, 1, []interface{}{1}),
+				win(0, 10, 2, []interface{}{1, 2}),
+				win(5, 15, 2, []interface{}{2, 3}),
+				win(10, 20, 1, []interface{}{3}),
+			},
+		},
+		{
+			desc: "session",
+			w:    window.Session(ts, 5*time.Second).Aggregate(values),
+			in:   readings(at(0, 1), at(3, 2), at(7, 3), at(20, 4)),
+			out: []interface{}{
+				win(0, 12, 3, []interface{}{1, 2, 3}),
+				win(20, 25, 1, []interface{}{4}),
+			},
+		},
+		{
+			desc: "session bridged out of order",
+			w:    window.Session(ts, 5*time.Second).AllowedLateness(10 * time.Second).Aggregate(values),
+			in:   readings(at(0, 1), at(8, 2), at(4, 3)),
+			out:  []interface{}{win(0, 13, 3, []interface{}{1, 3, 2})},
+		},
+		{
+			desc: "late elements dropped",
+			w:    window.Tumbling(ts, 10*time.Second).Aggregate(values),
+			in:   readings(at(1, 1), at(12, 2), at(3, 3)),
+			out:  []interface{}{win(0, 10, 1, []interface{}{1}), win(10, 20, 1, []interface{}{2})},
+		},
+		{
+			desc: "late elements allowed",
+			w:    window.Tumbling(ts, 10*time.Second).AllowedLateness(5 * time.Second).Aggregate(values),
+			in:   readings(at(1, 1), at(12, 2), at(3, 3), at(16, 4), at(4, 5)),
+			out:  []interface{}{win(0, 10, 2, []interface{}{1, 3}), win(10, 20, 2, []interface{}{2, 4})},
+		},
+		{
+			desc: "unreduced",
+			w:    window.Tumbling(ts, 10*time.Second),
+			in:   readings(at(1, 1)),
+			out:  []interface{}{win(0, 10, 1, readings(at(1, 1)))},
+		},
+		{
+			desc: "empty",
+			w:    window.Tumbling(ts, 10*time.Second),
+			in:   []interface{}{},
+			out:  []interface{}{},
+		},
+		{desc: "bad timestamp", w: window.Tumbling(Path("Value"), time.Second), in: readings(at(1, 1)), expectedErr: true},
+		{desc: "bad size", w: window.Tumbling(ts, 0), in: readings(at(1, 1)), expectedErr: true},
+		{desc: "bad slide", w: window.Sliding(ts, time.Second, 0), in: readings(at(1, 1)), expectedErr: true},
+		{desc: "bad lateness", w: window.Session(ts, time.Second).AllowedLateness(-time.Second), in: readings(at(1, 1)), expectedErr: true},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			res, err := Windows(ctx, tC.in, tC.w)
+			if tC.expectedErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tC.out, res)
+			}
+		})
+	}
+}
+
+func TestWindowOnLate(t *testing.T) {
+	var late []interface{}
+	w := window.Tumbling(Path("At"), 10*time.Second).OnLate(function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		late = append(late, i)
+		return nil, nil
+	}))
+	_, err := Windows(ctx, readings(at(1, 1), at(12, 2), at(3, 3)), w)
+	require.NoError(t, err)
+	assert.Equal(t, readings(at(3, 3)), late)
+
+	w = w.OnLate(function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		return nil, errors.New("late")
+	}))
+	_, err = Windows(ctx, readings(at(1, 1), at(12, 2), at(3, 3)), w)
+	assert.Error(t, err)
+
+	late = nil
+	w = window.Sliding(Path("At"), 5*time.Second, 10*time.Second).Aggregate(values).OnLate(function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		late = append(late, i)
+		return nil, nil
+	}))
+	ws, err := Windows(ctx, readings(at(1, 1), at(7, 2), at(12, 3), at(3, 4), at(8, 5)), w)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{win(0, 5, 1, []interface{}{1}), win(10, 15, 1, []interface{}{3})}, ws)
+	assert.Equal(t, readings(at(3, 4)), late)
+}
+
+func TestStreamWindowClosesEarly(t *testing.T) {
+	calls := 0
+	ts := function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		calls++
+		return i.(reading).At, nil
+	})
+	res, err := Lazy(ctx, readings(at(1, 1), at(12, 2), at(25, 3), at(40, 4))).
+		Window(window.Tumbling(ts, 10*time.Second).Aggregate(values)).
+		Take(1).
+		Interfaces()
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{win(0, 10, 1, []interface{}{1})}, res)
+	assert.Equal(t, 2, calls)
+}
+
+func TestProcessingTimeWindows(t *testing.T) {
+	now := epoch
+	clock := ClockFunc(func() time.Time {
+		now = now.Add(4 * time.Second)
+		return now
+	})
+	res, err := Ints(WithClock(ctx, clock), []int{1, 2, 3, 4, 5}).
+		Window(window.Tumbling(ProcessingTime(), 10*time.Second).Reduce(Sum())).
+		Map(Field("Value")).
+		Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 7, 5}, res)
+}
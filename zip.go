@@ -0,0 +1,210 @@
+package fu
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samwho/fu/bifunction"
+	"github.com/samwho/fu/function"
+	"github.com/samwho/fu/reducer"
+)
+
+type Pair struct {
+	First  interface{}
+	Second interface{}
+}
+
+type Tuple []interface{}
+
+func (t Tuple) Len() int {
+	return len(t)
+}
+
+func (t Tuple) Pair() (Pair, error) {
+	if len(t) != 2 {
+		return Pair{}, fmt.Errorf(`cannot make pair from tuple of length %d`, len(t))
+	}
+	return Pair{t[0], t[1]}, nil
+}
+
+func (p Pair) Tuple() Tuple {
+	return Tuple{p.First, p.Second}
+}
+
+func asTuple(i interface{}) (Tuple, error) {
+	switch t := i.(type) {
+	case Tuple:
+		return t, nil
+	case Pair:
+		return t.Tuple(), nil
+	case *Pair:
+		if t == nil {
+			return nil, fmt.Errorf(`cannot use nil pair as tuple`)
+		}
+		return t.Tuple(), nil
+	default:
+		return nil, fmt.Errorf(`cannot use non-tuple as tuple: %v`, i)
+	}
+}
+
+func Nth(n int) function.F {
+	return function.New(func(ctx context.Context, i interface{}) (interface{}, error) {
+		t, err := asTuple(i)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || n >= len(t) {
+			return nil, fmt.Errorf(`index %d out of range for tuple of length %d`, n, len(t))
+		}
+		return t[n], nil
+	})
+}
+
+func MakePair() bifunction.B {
+	return bifunction.New(func(ctx context.Context, i interface{}, j interface{}) (interface{}, error) {
+		return Pair{i, j}, nil
+	})
+}
+
+func zip(ctx context.Context, longest bool, fill interface{}, iss [][]interface{}) ([]interface{}, error) {
+	if len(iss) == 0 {
+		return []interface{}{}, nil
+	}
+	n := len(iss[0])
+	for _, is := range iss[1:] {
+		if longest && len(is) > n || !longest && len(is) < n {
+			n = len(is)
+		}
+	}
+
+	ret := make([]interface{}, 0, n)
+	for idx := 0; idx < n; idx++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		t := make(Tuple, len(iss))
+		for j, is := range iss {
+			if idx < len(is) {
+				t[j] = is[idx]
+			} else {
+				t[j] = fill
+			}
+		}
+		ret = append(ret, t)
+	}
+	return ret, nil
+}
+
+func zipWith(ctx context.Context, bf bifunction.B, longest bool, fill interface{}, iss [][]interface{}) ([]interface{}, error) {
+	ts, err := zip(ctx, longest, fill, iss)
+	if err != nil {
+		return nil, err
+	}
+	r := reducer.New(bf)
+	for idx, t := range ts {
+		ts[idx], err = r.Reduce(ctx, t.(Tuple))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ts, nil
+}
+
+func Zip(ctx context.Context, iss ...[]interface{}) ([]interface{}, error) {
+	return zip(ctx, false, nil, iss)
+}
+
+func ZipLongest(ctx context.Context, fill interface{}, iss ...[]interface{}) ([]interface{}, error) {
+	return zip(ctx, true, fill, iss)
+}
+
+func ZipWith(ctx context.Context, bf bifunction.B, iss ...[]interface{}) ([]interface{}, error) {
+	return zipWith(ctx, bf, false, nil, iss)
+}
+
+func ZipWithLongest(ctx context.Context, bf bifunction.B, fill interface{}, iss ...[]interface{}) ([]interface{}, error) {
+	return zipWith(ctx, bf, true, fill, iss)
+}
+
+func Unzip(ctx context.Context, is []interface{}) ([][]interface{}, error) {
+	if len(is) == 0 {
+		return [][]interface{}{}, nil
+	}
+	first, err := asTuple(is[0])
+	if err != nil {
+		return nil, err
+	}
+	ret := make([][]interface{}, len(first))
+	for j := range ret {
+		ret[j] = make([]interface{}, 0, len(is))
+	}
+	for _, i := range is {
+		t, err := asTuple(i)
+		if err != nil {
+			return nil, err
+		}
+		if len(t) != len(ret) {
+			return nil, fmt.Errorf(`cannot unzip tuples of differing lengths: %d and %d`, len(ret), len(t))
+		}
+		for j, v := range t {
+			ret[j] = append(ret[j], v)
+		}
+	}
+	return ret, nil
+}
+
+func (c *Collection) zip(os []*Collection, f func(iss [][]interface{}) ([]interface{}, error)) *Collection {
+	if c.err != nil {
+		return c
+	}
+	iss := make([][]interface{}, 0, len(os)+1)
+	iss = append(iss, c.is)
+	for _, o := range os {
+		if o.err != nil {
+			c.err = o.err
+			return c
+		}
+		iss = append(iss, o.is)
+	}
+	c.is, c.err = f(iss)
+	return c
+}
+
+func (c *Collection) Zip(os ...*Collection) *Collection {
+	return c.zip(os, func(iss [][]interface{}) ([]interface{}, error) {
+		return Zip(c.ctx, iss...)
+	})
+}
+
+func (c *Collection) ZipLongest(fill interface{}, os ...*Collection) *Collection {
+	return c.zip(os, func(iss [][]interface{}) ([]interface{}, error) {
+		return ZipLongest(c.ctx, fill, iss...)
+	})
+}
+
+func (c *Collection) ZipWith(bf bifunction.B, os ...*Collection) *Collection {
+	return c.zip(os, func(iss [][]interface{}) ([]interface{}, error) {
+		return ZipWith(c.ctx, bf, iss...)
+	})
+}
+
+func (c *Collection) ZipWithLongest(bf bifunction.B, fill interface{}, os ...*Collection) *Collection {
+	return c.zip(os, func(iss [][]interface{}) ([]interface{}, error) {
+		return ZipWithLongest(c.ctx, bf, fill, iss...)
+	})
+}
+
+func (c *Collection) Unzip() ([]*Collection, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	iss, err := Unzip(c.ctx, c.is)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Collection, 0, len(iss))
+	for _, is := range iss {
+		ret = append(ret, &Collection{c.ctx, is, nil})
+	}
+	return ret, nil
+}
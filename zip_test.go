@@ -0,0 +1,143 @@
+package fu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		f    func() ([]interface{}, error)
+		out  []interface{}
+	}{
+		{
+			desc: "shortest",
+			f: func() ([]interface{}, error) {
+				return Zip(ctx, []interface{}{1, 2, 3}, []interface{}{"a", "b"})
+			},
+			out: []interface{}{Tuple{1, "a"}, Tuple{2, "b"}},
+		},
+		{
+			desc: "longest",
+			f: func() ([]interface{}, error) {
+				return ZipLongest(ctx, "-", []interface{}{1, 2, 3}, []interface{}{"a", "b"})
+			},
+			out: []interface{}{Tuple{1, "a"}, Tuple{2, "b"}, Tuple{3, "-"}},
+		},
+		{
+			desc: "three inputs",
+			f: func() ([]interface{}, error) {
+				return Zip(ctx, []interface{}{1}, []interface{}{2}, []interface{}{3})
+			},
+			out: []interface{}{Tuple{1, 2, 3}},
+		},
+		{
+			desc: "none",
+			f: func() ([]interface{}, error) {
+				return Zip(ctx)
+			},
+			out: []interface{}{},
+		},
+		{
+			desc: "with sum",
+			f: func() ([]interface{}, error) {
+				return ZipWith(ctx, Sum(), []interface{}{1, 2, 3}, []interface{}{10, 20})
+			},
+			out: []interface{}{11, 22},
+		},
+		{
+			desc: "with sum longest",
+			f: func() ([]interface{}, error) {
+				return ZipWithLongest(ctx, Sum(), 0, []interface{}{1, 2, 3}, []interface{}{10, 20}, []interface{}{100})
+			},
+			out: []interface{}{111, 22, 3},
+		},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+			zipped, err := tC.f()
+			require.NoError(t, err)
+			assert.Equal(t, tC.out, zipped)
+		})
+	}
+}
+
+func TestZipWithError(t *testing.T) {
+	_, err := ZipWith(ctx, Sum(), []interface{}{1}, []interface{}{"a"})
+	assert.Error(t, err)
+}
+
+func TestUnzip(t *testing.T) {
+	iss, err := Unzip(ctx, []interface{}{Tuple{1, "a"}, Pair{2, "b"}})
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{1, 2}, {"a", "b"}}, iss)
+
+	_, err = Unzip(ctx, []interface{}{Tuple{1, "a"}, Tuple{2}})
+	assert.Error(t, err)
+
+	_, err = Unzip(ctx, []interface{}{1})
+	assert.Error(t, err)
+}
+
+func TestTupleAccessors(t *testing.T) {
+	p := Pair{1, "a"}
+
+	first, err := Field("First").Call(ctx, p)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first)
+
+	second, err := Nth(1).Call(ctx, p)
+	require.NoError(t, err)
+	assert.Equal(t, "a", second)
+
+	_, err = Nth(2).Call(ctx, Tuple{1, 2})
+	assert.Error(t, err)
+
+	q, err := Tuple{1, "a"}.Pair()
+	require.NoError(t, err)
+	assert.Equal(t, p, q)
+}
+
+func TestCollectionZip(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3}).Zip(Strings(ctx, []string{"a", "b", "c"})).Map(Nth(1)).Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, result)
+}
+
+func TestCollectionZipWith(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3}).ZipWith(Multiply(), Ints(ctx, []int{4, 5, 6})).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 10, 18}, result)
+}
+
+func TestCollectionZipWithLongest(t *testing.T) {
+	result, err := Ints(ctx, []int{1, 2, 3}).ZipWithLongest(Sum(), 0, Ints(ctx, []int{4})).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 2, 3}, result)
+}
+
+func TestCollectionZipAfterErr(t *testing.T) {
+	_, err := Ints(ctx, []int{1}).Zip(Strings(ctx, []string{"a"}).Map(Add(1))).Interfaces()
+	assert.Error(t, err)
+}
+
+func TestCollectionUnzip(t *testing.T) {
+	cs, err := Ints(ctx, []int{1, 2}).ZipLongest(nil, Strings(ctx, []string{"a", "b"})).Unzip()
+	require.NoError(t, err)
+	require.Len(t, cs, 2)
+
+	ints, err := cs[0].Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ints)
+
+	strs, err := cs[1].Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, strs)
+}